@@ -1,11 +1,17 @@
 package grpcmatchers
 
 import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
 	"github.com/jamillosantos/gomega-grpc/matchersimpl"
 )
 
 // HaveFieldValidation matches a given field and description (if informed) for a given errdetails.BadRequest or
 // errdetails.BadRequest_FieldViolation[] or errdetails.BadRequest_FieldViolation.
+//
+// The field is compared as is. To compare differently spelled field paths (eg: `items.0.sku` and `items[0].sku`), use
+// HaveFieldViolationPath.
 func HaveFieldViolation(fieldAndDescription ...string) *matchersimpl.GRPCStatusMatcher {
 	var field, description string
 	if len(fieldAndDescription) > 0 {
@@ -14,8 +20,51 @@ func HaveFieldViolation(fieldAndDescription ...string) *matchersimpl.GRPCStatusM
 	if len(fieldAndDescription) > 1 {
 		description = fieldAndDescription[1]
 	}
-	return matchersimpl.NewGRPCMatchBadRequest(&matchersimpl.GRPCBadRequestFieldViolation{
+	return matchersimpl.NewGRPCMatchBadRequest(FieldViolation(field, description))
+}
+
+// HaveFieldViolationPath works as HaveFieldViolation, but the field is compared with EqualFieldPath, after normalizing
+// both paths to the AIP-161 syntax. So `items.0.sku`, `Items[0].Sku` and `items[0].sku` are all the same field:
+//
+//	Expect(err).To(HaveFieldViolationPath("items[0].sku", "must not be empty"))
+func HaveFieldViolationPath(field string, description ...string) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchBadRequest(FieldViolationMatching(EqualFieldPath(field), optionalEqual(description)))
+}
+
+// HaveFieldViolationMatching works as HaveFieldViolation, but the field and description of the violations are matched
+// against the given matchers. A nil matcher matches any value, so an empty description can be asserted with
+// BeEmpty():
+//
+//	Expect(err).To(HaveFieldViolationMatching(Equal("book.title"), BeEmpty()))
+func HaveFieldViolationMatching(field, description types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchBadRequest(FieldViolationMatching(field, description))
+}
+
+// HaveExactlyFieldViolations matches the field violations of the errdetails.BadRequest in the *status.Status details
+// against the given violations, regardless of the order. It fails if any violation is missing or if there are extra
+// violations.
+//
+//	Expect(err).To(HaveExactlyFieldViolations(
+//		FieldViolation("book.title", "must not be empty"),
+//		FieldViolationMatching(Equal("book.isbn"), ContainSubstring("invalid")),
+//	))
+func HaveExactlyFieldViolations(violations ...*matchersimpl.GRPCFieldViolationMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchBadRequest(&matchersimpl.GRPCExactFieldViolationsMatcher{
+		Violations: violations,
+	})
+}
+
+// FieldViolation describes a field violation by its field and description (if informed), to be used with
+// HaveExactlyFieldViolations.
+func FieldViolation(field string, description ...string) *matchersimpl.GRPCFieldViolationMatcher {
+	return FieldViolationMatching(gomega.Equal(field), optionalEqual(description))
+}
+
+// FieldViolationMatching describes a field violation by matchers for its field and description, to be used with
+// HaveExactlyFieldViolations. A nil matcher matches any value.
+func FieldViolationMatching(field, description types.GomegaMatcher) *matchersimpl.GRPCFieldViolationMatcher {
+	return &matchersimpl.GRPCFieldViolationMatcher{
 		Field:       field,
 		Description: description,
-	})
+	}
 }
@@ -42,4 +42,111 @@ var _ = Describe("BadRequest", func() {
 			Expect(err).ToNot(HaveFieldViolation("field2", "description3"))
 		})
 	})
+
+	Describe("HaveFieldViolationPath", func() {
+		pathSt, stErr := status.New(codes.InvalidArgument, "random error").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "items.0.sku", Description: "must not be empty"},
+			},
+		})
+		Expect(stErr).ToNot(HaveOccurred())
+
+		It("should match differently spelled field paths", func() {
+			Expect(pathSt.Err()).To(HaveFieldViolationPath("items[0].sku"))
+			Expect(pathSt.Err()).To(HaveFieldViolationPath("Items[0].Sku", "must not be empty"))
+		})
+
+		It("should not match a different field path or description", func() {
+			Expect(pathSt.Err()).ToNot(HaveFieldViolationPath("items[1].sku"))
+			Expect(pathSt.Err()).ToNot(HaveFieldViolationPath("items[0].sku", "must be unique"))
+		})
+
+		It("should not normalize the paths on HaveFieldViolation", func() {
+			Expect(pathSt.Err()).ToNot(HaveFieldViolation("items[0].sku"))
+		})
+	})
+
+	Describe("HaveFieldViolationMatching", func() {
+		It("should match a field violation by matchers", func() {
+			Expect(err).To(HaveFieldViolationMatching(HavePrefix("field"), Equal("description3")))
+		})
+
+		It("should match any value with a nil matcher", func() {
+			Expect(err).To(HaveFieldViolationMatching(nil, Equal("description1")))
+		})
+
+		It("should assert an empty description", func() {
+			Expect(err).ToNot(HaveFieldViolationMatching(Equal("field1"), BeEmpty()))
+		})
+
+		It("should suggest the closest field name", func() {
+			Expect(HaveFieldViolation("feild1").FailureMessage(err)).To(ContainSubstring(`did you mean "field1"?`))
+		})
+	})
+
+	Describe("HaveExactlyFieldViolations", func() {
+		It("should match all the field violations regardless of the order", func() {
+			Expect(err).To(HaveExactlyFieldViolations(
+				FieldViolation("field3"),
+				FieldViolation("field1", "description1"),
+				FieldViolationMatching(Equal("field2"), HaveSuffix("2")),
+			))
+		})
+
+		It("should not match when a violation is missing", func() {
+			Expect(err).ToNot(HaveExactlyFieldViolations(
+				FieldViolation("field1"),
+				FieldViolation("field2"),
+				FieldViolation("field3"),
+				FieldViolation("field4"),
+			))
+		})
+
+		It("should not match when there are extra violations", func() {
+			Expect(err).ToNot(HaveExactlyFieldViolations(
+				FieldViolation("field1"),
+				FieldViolation("field2"),
+			))
+		})
+
+		It("should list the missing and extra violations", func() {
+			matcher := HaveExactlyFieldViolations(
+				FieldViolation("field1"),
+				FieldViolation("field2"),
+				FieldViolation("feild3"),
+			)
+			Expect(matcher.Match(err)).To(BeFalse())
+			message := matcher.FailureMessage(err)
+			Expect(message).To(ContainSubstring("the missing field violations were"))
+			Expect(message).To(ContainSubstring("the extra field violations were"))
+			Expect(message).To(ContainSubstring(`{"field":"field3","description":"description3"}`))
+			Expect(message).To(ContainSubstring(`did you mean "field3"?`))
+		})
+	})
 })
+
+func ExampleHaveFieldViolationMatching() {
+	st, err := status.New(codes.InvalidArgument, "message").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "book.title"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveFieldViolationMatching(Equal("book.title"), BeEmpty()))
+}
+
+func ExampleHaveExactlyFieldViolations() {
+	st, err := status.New(codes.InvalidArgument, "message").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "book.title", Description: "must not be empty"},
+			{Field: "book.isbn", Description: "invalid ISBN"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveExactlyFieldViolations(
+		FieldViolation("book.title", "must not be empty"),
+		FieldViolationMatching(Equal("book.isbn"), ContainSubstring("invalid")),
+	))
+}
@@ -0,0 +1,35 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// Conversation is a scripted conversation on a bidirectional stream. See Converse.
+type Conversation = matchersimpl.Conversation
+
+// Converse starts a scripted conversation on a bidirectional stream (eg: pb.Chat_ConnectClient). Each step runs as it
+// is called and the first one that fails is reported to Gomega, with a transcript of everything sent and received:
+//
+//	Converse(stream).
+//		Send(&pb.Message{Text: "hi"}).
+//		ExpectRecv(ProtoEqual(&pb.Message{Text: "hi there"})).
+//		CloseSend().
+//		ExpectStatus(HaveStatusCode(Equal(codes.OK)))
+//
+// All the steps share StreamTimeout, counted from the first step. Use Within to change it:
+//
+//	Converse(stream).Within(time.Second).Send(msg)...
+//
+// Wrap the stream with CancelOnTimeout to cancel its context, and stop the pending Send or Recv, when a step times
+// out.
+func Converse(stream interface{}) *Conversation {
+	return ConverseWith(gomega.Default, stream)
+}
+
+// ConverseWith works as Converse, but reports the failures to the given Gomega (eg: the one given by Eventually to the
+// polled functions or created by NewWithT).
+func ConverseWith(g gomega.Gomega, stream interface{}) *Conversation {
+	return matchersimpl.NewConversation(g, stream, StreamTimeout)
+}
@@ -0,0 +1,75 @@
+package grpcmatchers
+
+import (
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// echoStream stands for a generated bidirectional client stream that echoes the messages sent. It ends with io.EOF
+// once the sending direction is closed.
+type echoStream struct {
+	messages chan *errdetails.ErrorInfo
+}
+
+func newEchoStream() *echoStream {
+	return &echoStream{messages: make(chan *errdetails.ErrorInfo, 10)}
+}
+
+func (s *echoStream) Send(m *errdetails.ErrorInfo) error {
+	s.messages <- m
+	return nil
+}
+
+func (s *echoStream) Recv() (*errdetails.ErrorInfo, error) {
+	m, ok := <-s.messages
+	if !ok {
+		return nil, io.EOF
+	}
+	return m, nil
+}
+
+func (s *echoStream) CloseSend() error {
+	close(s.messages)
+	return nil
+}
+
+var _ = Describe("Converse", func() {
+	a := &errdetails.ErrorInfo{Reason: "A"}
+
+	It("should run the conversation", func() {
+		Converse(newEchoStream()).
+			Send(a).
+			ExpectRecv(ProtoEqual(a)).
+			CloseSend().
+			ExpectStatus(HaveStatusCode(Equal(codes.OK)))
+	})
+
+	It("should report the failures to the given Gomega", func() {
+		var failures []string
+		g := NewGomega(func(message string, _ ...int) {
+			failures = append(failures, message)
+		})
+
+		ConverseWith(g, newEchoStream()).
+			Within(time.Second).
+			Send(a).
+			ExpectRecv(ProtoEqual(&errdetails.ErrorInfo{Reason: "B"})).
+			CloseSend()
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0]).To(ContainSubstring("Conversation failed at step 2 (expect recv):"))
+		Expect(failures[0]).To(ContainSubstring(`reason: "B" -> "A"`))
+	})
+})
+
+func ExampleConverse() {
+	Converse(newEchoStream()).
+		Send(&errdetails.ErrorInfo{Reason: "HELLO"}).
+		ExpectRecv(ProtoEqual(&errdetails.ErrorInfo{Reason: "HELLO"})).
+		CloseSend().
+		ExpectStatus(HaveStatusCode(Equal(codes.OK)))
+}
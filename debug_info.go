@@ -0,0 +1,22 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveDebugStackEntries will match the *errdetails.DebugInfo StackEntries property, as a []string, against the given
+// matcher.
+//
+//	Expect(err).To(HaveDebugStackEntries(ContainElement(ContainSubstring("handler.go"))))
+func HaveDebugStackEntries(matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailItemsMatcher[*errdetails.DebugInfo, *errdetails.DebugInfo]{
+		Description: "a debug info",
+		Items:       detailItself[*errdetails.DebugInfo],
+		Props: []matchersimpl.DetailProp[*errdetails.DebugInfo]{
+			{Name: "stack_entries", PropMap: func(d *errdetails.DebugInfo) interface{} { return d.GetStackEntries() }, Matcher: matcher},
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("DebugInfo", func() {
+	st, err := status.New(codes.Internal, "random error").WithDetails(&errdetails.DebugInfo{
+		StackEntries: []string{"main.handler(handler.go:42)", "main.main(main.go:10)"},
+		Detail:       "nil pointer dereference",
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveDebugStackEntries", func() {
+		It("should match the stack entries", func() {
+			Expect(err).To(HaveDebugStackEntries(HaveLen(2)))
+			Expect(err).To(HaveDebugStackEntries(ContainElement(ContainSubstring("handler.go"))))
+		})
+
+		It("should not match different stack entries", func() {
+			Expect(err).ToNot(HaveDebugStackEntries(BeEmpty()))
+		})
+	})
+})
+
+func ExampleHaveDebugStackEntries() {
+	st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.DebugInfo{
+		StackEntries: []string{"main.handler(handler.go:42)"},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveDebugStackEntries(ConsistOf("main.handler(handler.go:42)")))
+}
@@ -0,0 +1,35 @@
+package grpcmatchers
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// FieldPathOption configures the matcher created by EqualFieldPath.
+type FieldPathOption func(*matchersimpl.GRPCFieldPathMatcher)
+
+// EqualFieldPath matches a field path, such as the field of a BadRequest field violation, after normalizing both the
+// actual and the expected paths to the AIP-161 syntax. So `items.0.sku`, `Items[0].Sku` and `items[0].sku` are all
+// the same path:
+//
+//	Expect(err).To(HaveFieldViolationMatching(EqualFieldPath("items[0].sku", ForRequest(&pb.CreateOrderRequest{})), nil))
+func EqualFieldPath(path string, opts ...FieldPathOption) *matchersimpl.GRPCFieldPathMatcher {
+	matcher := &matchersimpl.GRPCFieldPathMatcher{
+		Expected: path,
+	}
+	for _, opt := range opts {
+		opt(matcher)
+	}
+	return matcher
+}
+
+// ForRequest validates the expected path against the descriptor of the given request message. The match fails with
+// an error if the path does not exist, so tests do not keep asserting on fields removed from the proto. A nil request
+// fails the match with an error too.
+func ForRequest(request proto.Message) FieldPathOption {
+	return func(m *matchersimpl.GRPCFieldPathMatcher) {
+		m.Request = request
+		m.ValidateRequest = true
+	}
+}
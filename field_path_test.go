@@ -0,0 +1,64 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var _ = Describe("FieldPath", func() {
+	st, err := status.New(codes.InvalidArgument, "random error").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "messageType.0.field.1.name", Description: "must not be empty"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("EqualFieldPath", func() {
+		It("should match differently spelled paths", func() {
+			Expect(err).To(HaveFieldViolationMatching(EqualFieldPath("message_type[0].field[1].name"), nil))
+			Expect(err).To(HaveFieldViolationMatching(EqualFieldPath("MessageType[0].Field[1].Name"), nil))
+		})
+
+		It("should not match a different path", func() {
+			Expect(err).ToNot(HaveFieldViolationMatching(EqualFieldPath("message_type[0].field[0].name"), nil))
+		})
+
+		It("should be used with HaveExactlyFieldViolations", func() {
+			Expect(err).To(HaveExactlyFieldViolations(
+				FieldViolationMatching(EqualFieldPath("message_type[0].field[1].name"), Equal("must not be empty")),
+			))
+		})
+	})
+
+	Describe("ForRequest", func() {
+		It("should match a path of the request", func() {
+			Expect(err).To(HaveFieldViolationMatching(EqualFieldPath("message_type[0].field[1].name", ForRequest(&descriptorpb.FileDescriptorProto{})), nil))
+		})
+
+		It("should fail with a path that does not exist in the request", func() {
+			_, matchErr := HaveFieldViolationMatching(EqualFieldPath("message_type[0].fields[1].name", ForRequest(&descriptorpb.FileDescriptorProto{})), nil).Match(err)
+			Expect(matchErr).To(MatchError(ContainSubstring(`did you mean "field"?`)))
+		})
+
+		It("should fail with a nil request", func() {
+			_, matchErr := HaveFieldViolationMatching(EqualFieldPath("message_type[0].field[1].name", ForRequest(nil)), nil).Match(err)
+			Expect(matchErr).To(MatchError(ContainSubstring("nil request")))
+		})
+	})
+})
+
+func ExampleEqualFieldPath() {
+	st, err := status.New(codes.InvalidArgument, "message").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "messageType.0.name"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveFieldViolationMatching(EqualFieldPath("message_type[0].name", ForRequest(&descriptorpb.FileDescriptorProto{})), nil))
+}
@@ -1,40 +1,94 @@
 package grpcmatchers
 
 import (
-	"strconv"
+	"fmt"
+	"sync"
 
 	"github.com/onsi/gomega/format"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
 )
 
-type errorFormat struct {
-	Status     string
-	StatusCode string
-	Message    string
-	Details    []interface{}
-}
+// DetailsFormat selects how the status details are rendered on failure messages.
+type DetailsFormat = matchersimpl.DetailFormat
 
-type errorInfo struct {
+const (
+	// DetailsJSON renders each detail as protojson, preceded by its type URL.
+	DetailsJSON = matchersimpl.DetailFormatJSON
+	// DetailsText renders each detail as prototext, preceded by its type URL.
+	DetailsText = matchersimpl.DetailFormatText
+)
+
+// SetStatusDetailsFormat changes the DetailsFormat used to render the status details on failure messages, returning
+// the previous one. It defaults to DetailsJSON:
+//
+//	defer SetStatusDetailsFormat(SetStatusDetailsFormat(DetailsText))
+func SetStatusDetailsFormat(detailsFormat DetailsFormat) DetailsFormat {
+	return matchersimpl.SetStatusDetailsFormat(detailsFormat)
 }
 
+// grpcErrorFormatter renders grpc errors (including the ones wrapped with %w), *status.Status and *spb.Status with
+// their code name and number, message and decoded details.
 func grpcErrorFormatter(value interface{}) (string, bool) {
-	err, ok := value.(error)
+	st, ok := matchersimpl.StatusFrom(value)
+	if !ok {
+		return "", false
+	}
+	return matchersimpl.FormatStatus(st), true
+}
+
+// codeFormatter renders codes.Code with their name and number (eg: NotFound (5)).
+func codeFormatter(value interface{}) (string, bool) {
+	code, ok := value.(codes.Code)
 	if !ok {
 		return "", false
 	}
-	st, ok := status.FromError(err)
+	return fmt.Sprintf("%s (%d)", code, code), true
+}
+
+// protoMessageFormatter renders proto.Message values in a single line, using the status details format (see
+// SetStatusDetailsFormat), instead of the internals of the generated Go structs.
+func protoMessageFormatter(value interface{}) (string, bool) {
+	m, ok := value.(proto.Message)
 	if !ok {
 		return "", false
 	}
-	return format.Object(errorFormat{
-		Status:     st.Code().String(),
-		StatusCode: strconv.Itoa(int(st.Code())),
-		Message:    st.Message(),
-		Details:    st.Details(),
-	}, 0), true
+	return matchersimpl.FormatMessage(m, matchersimpl.StatusDetailsFormat()), true
 }
 
-func init() {
-	format.UseStringerRepresentation = true
-	_ = format.RegisterCustomFormatter(grpcErrorFormatter)
+var (
+	formatterMu   sync.Mutex
+	formatterKeys []format.CustomFormatterKey
+)
+
+// RegisterFormatter registers the Gomega custom formatters of this package, changing how the failure messages of the
+// whole suite render:
+//   - grpc errors, *status.Status and *spb.Status, with their code, message and details;
+//   - codes.Code, with their name and number;
+//   - proto.Message, as protojson (or prototext, see SetStatusDetailsFormat).
+//
+// Importing the package does not register them. Call it once, usually in the suite setup, and UnregisterFormatter to
+// restore the default formatting. Calling it more than once has no effect.
+func RegisterFormatter() {
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+	if len(formatterKeys) > 0 {
+		return
+	}
+	// grpcErrorFormatter comes first, so *spb.Status is not rendered as a regular proto.Message.
+	for _, formatter := range []format.CustomFormatter{grpcErrorFormatter, codeFormatter, protoMessageFormatter} {
+		formatterKeys = append(formatterKeys, format.RegisterCustomFormatter(formatter))
+	}
+}
+
+// UnregisterFormatter removes the formatters registered by RegisterFormatter.
+func UnregisterFormatter() {
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+	for _, key := range formatterKeys {
+		format.UnregisterCustomFormatter(key)
+	}
+	formatterKeys = nil
 }
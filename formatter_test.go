@@ -0,0 +1,171 @@
+package grpcmatchers
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gstruct"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+var _ = Describe("grpcErrorFormatter", func() {
+	st, err := status.New(codes.NotFound, "book not found").WithDetails(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})
+	Expect(err).ToNot(HaveOccurred())
+
+	BeforeEach(func() {
+		RegisterFormatter()
+	})
+
+	AfterEach(func() {
+		UnregisterFormatter()
+	})
+
+	It("should format grpc errors, *status.Status and *spb.Status the same way", func() {
+		for _, value := range []interface{}{st.Err(), st, st.Proto()} {
+			formatted := format.Object(value, 0)
+			Expect(formatted).To(ContainSubstring("Status: NotFound,"))
+			Expect(formatted).To(ContainSubstring("StatusCode: 5,"))
+			Expect(formatted).To(ContainSubstring("Message: book not found,"))
+			Expect(formatted).To(ContainSubstring("BOOK_NOT_FOUND"))
+		}
+	})
+
+	It("should render the details with their type URLs", func() {
+		formatted := format.Object(st, 0)
+		Expect(formatted).To(ContainSubstring(`type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"}`))
+		Expect(formatted).ToNot(ContainSubstring("sizeCache"))
+	})
+
+	It("should render the details as prototext", func() {
+		defer SetStatusDetailsFormat(SetStatusDetailsFormat(DetailsText))
+		Expect(format.Object(st, 0)).To(MatchRegexp(`type.googleapis.com/google.rpc.ErrorInfo: \{reason:\s*"BOOK_NOT_FOUND"\}`))
+	})
+
+	It("should render unknown details with a hex preview", func() {
+		unknown := status.FromProto(&spb.Status{
+			Code:    int32(codes.Internal),
+			Details: []*anypb.Any{{TypeUrl: "type.googleapis.com/unknown.Detail", Value: []byte{0x08, 0x01}}},
+		})
+		Expect(format.Object(unknown, 0)).To(ContainSubstring("type.googleapis.com/unknown.Detail: <unknown type> 0801 (2 bytes)"))
+	})
+
+	It("should format the errors wrapping grpc errors", func() {
+		formatted := format.Object(fmt.Errorf("fetching book: %w", st.Err()), 0)
+		Expect(formatted).To(ContainSubstring("Status: NotFound,"))
+		Expect(formatted).To(ContainSubstring("Message: book not found,"))
+	})
+
+	It("should not format other errors", func() {
+		_, ok := grpcErrorFormatter(errors.New("random error"))
+		Expect(ok).To(BeFalse())
+	})
+})
+
+// The failure messages must read well without RegisterFormatter, so these specs run without it.
+var _ = Describe("failure messages", func() {
+	st, err := status.New(codes.NotFound, "book not found").WithDetails(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})
+	Expect(err).ToNot(HaveOccurred())
+
+	It("should render the full status on code failures", func() {
+		message := matchersimpl.MatchGRPCStatusCode(codes.Internal).FailureMessage(st.Err())
+		Expect(message).To(ContainSubstring("Status: NotFound,"))
+		Expect(message).To(ContainSubstring("to match the code\n    <string>: Internal (13)"))
+		Expect(message).To(ContainSubstring("BOOK_NOT_FOUND"))
+
+		negated := matchersimpl.MatchGRPCStatusCode(codes.NotFound).NegatedFailureMessage(st.Err())
+		Expect(negated).To(ContainSubstring("not to match the code\n    <string>: NotFound (5)"))
+	})
+
+	It("should render the full status on status failures", func() {
+		message := HaveStatusMessage(Equal("author not found")).FailureMessage(st.Err())
+		Expect(message).To(ContainSubstring("to equal"))
+		Expect(message).To(ContainSubstring("Status: NotFound,"))
+		Expect(message).To(ContainSubstring("BOOK_NOT_FOUND"))
+	})
+
+	It("should render the details on details failures", func() {
+		message := HaveStatusDetails(BeEmpty()).FailureMessage(st.Err())
+		Expect(message).To(ContainSubstring(`type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"}`))
+		Expect(message).ToNot(ContainSubstring("sizeCache"))
+	})
+
+	It("should render the code and details on status fields failures", func() {
+		matcher := MatchStatus(StatusFields{Code: Equal(codes.Internal), Details: BeEmpty()})
+		Expect(matcher.Match(st.Err())).To(BeFalse())
+		message := matcher.FailureMessage(st.Err())
+		Expect(message).To(ContainSubstring("NotFound (5)"))
+		Expect(message).To(ContainSubstring("Internal (13)"))
+		Expect(message).To(ContainSubstring(`type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"}`))
+		Expect(message).ToNot(ContainSubstring("sizeCache"))
+	})
+
+	It("should render the detail on detail failures", func() {
+		matcher := HaveStatusDetail[*errdetails.ErrorInfo](HaveField("Reason", "AUTHOR_NOT_FOUND"))
+		Expect(matcher.Match(st.Err())).To(BeFalse())
+		message := matcher.FailureMessage(st.Err())
+		Expect(message).To(ContainSubstring(`{"reason":"BOOK_NOT_FOUND"}`))
+	})
+
+	It("should render the proto on proto fields failures", func() {
+		detail := &errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"}
+		matcher := MatchProtoFields(gstruct.IgnoreExtras, ProtoFields{"reason": Equal("AUTHOR_NOT_FOUND")})
+		Expect(matcher.Match(detail)).To(BeFalse())
+		message := matcher.FailureMessage(detail)
+		Expect(message).To(ContainSubstring(`{"reason":"BOOK_NOT_FOUND"}`))
+		Expect(message).ToNot(ContainSubstring("sizeCache"))
+	})
+
+	It("should render the status and the response on RPC result failures", func() {
+		message := SucceedWith(nil).FailureMessage(Result(nil, st.Err()))
+		Expect(message).To(ContainSubstring("Status: NotFound,"))
+		Expect(message).ToNot(ContainSubstring("sizeCache"))
+
+		message = FailWith(nil).FailureMessage(Result(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"}, nil))
+		Expect(message).To(ContainSubstring(`{"reason":"BOOK_NOT_FOUND"}`))
+		Expect(message).ToNot(ContainSubstring("sizeCache"))
+	})
+})
+
+var _ = Describe("RegisterFormatter", func() {
+	st := status.New(codes.NotFound, "book not found")
+
+	BeforeEach(func() {
+		RegisterFormatter()
+	})
+
+	AfterEach(func() {
+		UnregisterFormatter()
+	})
+
+	It("should render codes.Code with their names and numbers", func() {
+		Expect(format.Object(codes.NotFound, 0)).To(Equal("<codes.Code>: NotFound (5)"))
+	})
+
+	It("should render proto.Message as protojson", func() {
+		formatted := format.Object(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"}, 0)
+		Expect(formatted).To(HavePrefix("<*errdetails.ErrorInfo | 0x"))
+		Expect(formatted).To(HaveSuffix(`{"reason":"BOOK_NOT_FOUND"}`))
+	})
+
+	It("should not register the formatters twice", func() {
+		RegisterFormatter()
+		UnregisterFormatter()
+		Expect(format.Object(st, 0)).ToNot(ContainSubstring("StatusCode: 5,"))
+	})
+
+	It("should restore the default formatting when unregistered", func() {
+		UnregisterFormatter()
+		Expect(format.Object(st, 0)).ToNot(ContainSubstring("StatusCode: 5,"))
+		Expect(format.Object(codes.NotFound, 0)).ToNot(ContainSubstring("NotFound (5)"))
+		Expect(format.Object(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"}, 0)).ToNot(HaveSuffix(`{"reason":"BOOK_NOT_FOUND"}`))
+	})
+})
@@ -3,7 +3,6 @@ module github.com/jamillosantos/gomega-grpc
 go 1.18
 
 require (
-	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883
 	github.com/golang/mock v1.6.0
 	github.com/jamillosantos/gomock-grpc v0.0.0-20211123010920-a5c1f3b04410
 	github.com/onsi/ginkgo v1.16.4
@@ -22,7 +21,6 @@ require (
 	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/nxadm/tail v1.4.8 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/sergi/go-diff v1.3.1 // indirect
 	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
 	golang.org/x/net v0.7.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
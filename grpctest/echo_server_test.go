@@ -0,0 +1,81 @@
+package grpctest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	pb "google.golang.org/grpc/examples/features/proto/echo"
+	"google.golang.org/grpc/status"
+)
+
+// echoServer echoes the messages it receives. Server streams send each word of the message on its own response.
+// Messages equal to "fail" are rejected with InvalidArgument.
+type echoServer struct {
+	pb.UnimplementedEchoServer
+}
+
+func registerEcho(s *grpc.Server) {
+	pb.RegisterEchoServer(s, &echoServer{})
+}
+
+func validateEcho(req *pb.EchoRequest) error {
+	if req.GetMessage() == "fail" {
+		return status.Error(codes.InvalidArgument, "invalid message")
+	}
+	return nil
+}
+
+func (*echoServer) UnaryEcho(_ context.Context, req *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if err := validateEcho(req); err != nil {
+		return nil, err
+	}
+	return &pb.EchoResponse{Message: req.GetMessage()}, nil
+}
+
+func (*echoServer) ServerStreamingEcho(req *pb.EchoRequest, stream pb.Echo_ServerStreamingEchoServer) error {
+	if err := validateEcho(req); err != nil {
+		return err
+	}
+	for _, word := range strings.Fields(req.GetMessage()) {
+		if err := stream.Send(&pb.EchoResponse{Message: word}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*echoServer) ClientStreamingEcho(stream pb.Echo_ClientStreamingEchoServer) error {
+	var words []string
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&pb.EchoResponse{Message: strings.Join(words, " ")})
+		}
+		if err != nil {
+			return err
+		}
+		words = append(words, req.GetMessage())
+	}
+}
+
+func (*echoServer) BidirectionalStreamingEcho(stream pb.Echo_BidirectionalStreamingEchoServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateEcho(req); err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.EchoResponse{Message: req.GetMessage()}); err != nil {
+			return err
+		}
+	}
+}
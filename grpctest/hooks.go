@@ -0,0 +1,100 @@
+package grpctest
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo"
+	"google.golang.org/grpc"
+)
+
+// Start creates a Server with NewServer, failing the test if it cannot be started, and closes it when the test and
+// its subtests finish. Failures to close the server, such as leaked streams, fail the test. It returns the ready client
+// connection:
+//
+//	func TestGetBook(t *testing.T) {
+//		client := pb.NewBooksClient(grpctest.Start(t, func(s *grpc.Server) {
+//			pb.RegisterBooksServer(s, &booksServer{})
+//		}))
+//		...
+//	}
+func Start(t testing.TB, register func(*grpc.Server), opts ...Option) *grpc.ClientConn {
+	t.Helper()
+	server, err := NewServer(register, opts...)
+	if err != nil {
+		t.Fatalf("grpctest: starting the server: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("grpctest: closing the server: %v", err)
+		}
+	})
+	return server.Conn
+}
+
+// GinkgoServer gives access to the Server started, for each spec, by Ginkgo.
+type GinkgoServer struct {
+	server *Server
+}
+
+// Server returns the Server of the running spec.
+func (g *GinkgoServer) Server() *Server {
+	return g.server
+}
+
+// Conn returns the client connection to the Server of the running spec.
+func (g *GinkgoServer) Conn() *grpc.ClientConn {
+	return g.server.Conn
+}
+
+// Ginkgo registers, in the current container, a BeforeEach that starts a new Server with NewServer and an AfterEach
+// that closes it. Failures to start or to close the server, such as leaked streams, fail the spec:
+//
+//	var _ = Describe("Books", func() {
+//		server := grpctest.Ginkgo(func(s *grpc.Server) {
+//			pb.RegisterBooksServer(s, &booksServer{})
+//		})
+//
+//		It("should get a book", func() {
+//			client := pb.NewBooksClient(server.Conn())
+//			...
+//		})
+//	})
+//
+// The hooks can run any number of times, each spec getting its own Server.
+func Ginkgo(register func(*grpc.Server), opts ...Option) *GinkgoServer {
+	g := &GinkgoServer{}
+	ginkgo.BeforeEach(func() {
+		if err := g.start(register, opts...); err != nil {
+			ginkgo.Fail("grpctest: starting the server: " + err.Error())
+		}
+	})
+	ginkgo.AfterEach(func() {
+		if err := g.stop(); err != nil {
+			ginkgo.Fail("grpctest: closing the server: " + err.Error())
+		}
+	})
+	return g
+}
+
+// start starts a new Server, closing the one left by a previous spec, if any.
+func (g *GinkgoServer) start(register func(*grpc.Server), opts ...Option) error {
+	if err := g.stop(); err != nil {
+		return err
+	}
+	server, err := NewServer(register, opts...)
+	if err != nil {
+		return err
+	}
+	g.server = server
+	return nil
+}
+
+// stop closes the Server of the running spec. It does nothing if there is no Server running.
+func (g *GinkgoServer) stop() error {
+	if g.server == nil {
+		return nil
+	}
+	server := g.server
+	g.server = nil
+	return server.Close()
+}
@@ -0,0 +1,79 @@
+package grpctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	pb "google.golang.org/grpc/examples/features/proto/echo"
+)
+
+func TestStart(t *testing.T) {
+	conn := Start(t, registerEcho)
+
+	resp, err := pb.NewEchoClient(conn).UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetMessage() != "hello" {
+		t.Fatalf("unexpected message: %q", resp.GetMessage())
+	}
+}
+
+func TestGinkgoServer_restart(t *testing.T) {
+	g := &GinkgoServer{}
+	var servers []*Server
+	for i := 0; i < 2; i++ {
+		if err := g.start(registerEcho); err != nil {
+			t.Fatalf("unexpected error starting the server: %v", err)
+		}
+		if _, err := pb.NewEchoClient(g.Conn()).UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		servers = append(servers, g.Server())
+		if err := g.stop(); err != nil {
+			t.Fatalf("unexpected error closing the server: %v", err)
+		}
+		if err := g.stop(); err != nil {
+			t.Fatalf("closing twice should do nothing, got: %v", err)
+		}
+	}
+	if servers[0] == servers[1] {
+		t.Fatal("expected a new server on each start")
+	}
+}
+
+func TestGinkgoServer_startClosesPrevious(t *testing.T) {
+	g := &GinkgoServer{}
+	if err := g.start(registerEcho); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	previous := g.Server()
+	if err := g.start(registerEcho); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer g.stop()
+	if err := previous.Close(); !errors.Is(err, errServerClosed) {
+		t.Fatalf("expected the previous server to be closed, got: %v", err)
+	}
+}
+
+var _ = Describe("Ginkgo", func() {
+	server := Ginkgo(registerEcho)
+
+	var previous *Server
+
+	It("should start a server for the spec", func() {
+		resp, err := pb.NewEchoClient(server.Conn()).UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.GetMessage()).To(Equal("hello"))
+		previous = server.Server()
+	})
+
+	It("should start a new server for each spec", func() {
+		Expect(server.Server()).ToNot(BeIdenticalTo(previous))
+		Expect(previous.Close()).To(MatchError(errServerClosed))
+	})
+})
@@ -0,0 +1,197 @@
+package grpctest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// Call is a call handled by a server with a Recorder.
+type Call struct {
+	// Method is the full method name (eg: /pkg.Service/Method).
+	Method string
+	// Metadata is the incoming metadata of the call.
+	Metadata metadata.MD
+	// Requests are the messages received by the server. Unary calls have a single request.
+	Requests []proto.Message
+	// Responses are the messages sent by the server. Unary calls have a single response, unless they failed.
+	Responses []proto.Message
+	// Status is the status returned by the server.
+	Status *status.Status
+}
+
+// Recorder is a unary and stream server interceptor that records every call handled by the server. Calls are recorded
+// when the handlers return, so a unary call is already recorded when the client receives its response.
+//
+// Use WithRecorder to add it to a Server and HaveReceivedCall, HaveReceivedCallTimes and HaveReturnedStatus to assert
+// on the calls:
+//
+//	recorder := grpctest.NewRecorder()
+//	conn := grpctest.Start(t, register, grpctest.WithRecorder(recorder))
+//	...
+//	Expect(recorder).To(grpctest.HaveReceivedCall("/pkg.Books/CreateBook", ProtoEqual(req)))
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// WithRecorder adds the interceptors of the recorder to the Server.
+func WithRecorder(recorder *Recorder) Option {
+	return WithServerOptions(
+		grpc.ChainUnaryInterceptor(recorder.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(recorder.StreamServerInterceptor()),
+	)
+}
+
+// Calls returns the recorded calls, in the order they finished.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to the given full method name, in the order they finished.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset discards all the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *Recorder) record(call Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// UnaryServerInterceptor returns the interceptor that records the unary calls.
+func (r *Recorder) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		call := newCall(ctx, info.FullMethod)
+		call.Requests = appendMessage(call.Requests, req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			call.Responses = appendMessage(call.Responses, resp)
+		}
+		call.Status = status.Convert(err)
+		r.record(call)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor that records the stream calls.
+func (r *Recorder) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		stream := &recordingStream{
+			ServerStream: ss,
+			call:         newCall(ss.Context(), info.FullMethod),
+		}
+		err := handler(srv, stream)
+		stream.mu.Lock()
+		call := stream.call
+		stream.mu.Unlock()
+		call.Status = status.Convert(err)
+		r.record(call)
+		return err
+	}
+}
+
+func newCall(ctx context.Context, method string) Call {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return Call{
+		Method:   method,
+		Metadata: md.Copy(),
+	}
+}
+
+// appendMessage appends a copy of m, if it is a proto.Message, so changes made to it after the call do not change
+// the recorded messages.
+func appendMessage(messages []proto.Message, m interface{}) []proto.Message {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return messages
+	}
+	return append(messages, proto.Clone(msg))
+}
+
+// recordingStream records the messages received and sent by a stream.
+type recordingStream struct {
+	grpc.ServerStream
+
+	mu   sync.Mutex
+	call Call
+}
+
+func (s *recordingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.call.Requests = appendMessage(s.call.Requests, m)
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *recordingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.call.Responses = appendMessage(s.call.Responses, m)
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// GomegaString renders the recorded calls on the failure messages, one per line, with their statuses and messages.
+func (r *Recorder) GomegaString() string {
+	calls := r.Calls()
+	if len(calls) == 0 {
+		return "<no calls>"
+	}
+	lines := make([]string, 0, len(calls))
+	for _, call := range calls {
+		lines = append(lines, formatCall(call))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatCall(call Call) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s (%d)", call.Method, call.Status.Code(), call.Status.Code())
+	if call.Status.Message() != "" {
+		fmt.Fprintf(&sb, " %q", call.Status.Message())
+	}
+	for _, req := range call.Requests {
+		fmt.Fprintf(&sb, "\n%srequest: %s", format.Indent, matchersimpl.FormatMessage(req, matchersimpl.DetailFormatJSON))
+	}
+	for _, resp := range call.Responses {
+		fmt.Fprintf(&sb, "\n%sresponse: %s", format.Indent, matchersimpl.FormatMessage(resp, matchersimpl.DetailFormatJSON))
+	}
+	return sb.String()
+}
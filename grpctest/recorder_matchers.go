@@ -0,0 +1,160 @@
+package grpctest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errExpectedRecorder = errors.New("the given object is not a *grpctest.Recorder")
+)
+
+func recorderFromActual(actual interface{}) (*Recorder, error) {
+	recorder, ok := actual.(*Recorder)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %T", errExpectedRecorder, actual)
+	}
+	return recorder, nil
+}
+
+// HaveReceivedCall succeeds if the Recorder has a call to the given full method name. If request matchers are given,
+// one of the requests of the call must match all of them:
+//
+//	Expect(recorder).To(HaveReceivedCall("/pkg.Books/CreateBook", ProtoEqual(req)))
+func HaveReceivedCall(method string, request ...types.GomegaMatcher) types.GomegaMatcher {
+	matcher := &receivedCallMatcher{Method: method}
+	if len(request) > 0 {
+		matcher.Request = gomega.And(request...)
+	}
+	return matcher
+}
+
+// HaveReceivedCallTimes succeeds if the Recorder has exactly the given number of calls to the full method name.
+func HaveReceivedCallTimes(method string, times int) types.GomegaMatcher {
+	return &receivedCallTimesMatcher{Method: method, Times: times}
+}
+
+// HaveReturnedStatus succeeds if any of the calls to the full method name returned a status matched by the given
+// matcher, usually one of the status matchers:
+//
+//	Expect(recorder).To(HaveReturnedStatus("/pkg.Books/CreateBook", HaveStatusCode(Equal(codes.AlreadyExists))))
+func HaveReturnedStatus(method string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &returnedStatusMatcher{Method: method, Status: matcher}
+}
+
+type receivedCallMatcher struct {
+	Method  string
+	Request types.GomegaMatcher
+
+	lastRequest proto.Message
+}
+
+func (m *receivedCallMatcher) Match(actual interface{}) (bool, error) {
+	recorder, err := recorderFromActual(actual)
+	if err != nil {
+		return false, err
+	}
+	m.lastRequest = nil
+	for _, call := range recorder.CallsTo(m.Method) {
+		if m.Request == nil {
+			return true, nil
+		}
+		for _, req := range call.Requests {
+			success, err := m.Request.Match(req)
+			if err != nil {
+				return false, err
+			}
+			if success {
+				return true, nil
+			}
+			m.lastRequest = req
+		}
+	}
+	return false, nil
+}
+
+func (m *receivedCallMatcher) FailureMessage(actual interface{}) string {
+	if m.Request == nil {
+		return format.Message(actual, "to have received a call to "+m.Method)
+	}
+	message := format.Message(actual, "to have received a call to "+m.Method+" with a matching request")
+	if m.lastRequest != nil {
+		message += "\nThe last request did not match:\n" + format.IndentString(m.Request.FailureMessage(m.lastRequest), 1)
+	}
+	return message
+}
+
+func (m *receivedCallMatcher) NegatedFailureMessage(actual interface{}) string {
+	if m.Request == nil {
+		return format.Message(actual, "not to have received a call to "+m.Method)
+	}
+	return format.Message(actual, "not to have received a call to "+m.Method+" with a matching request")
+}
+
+type receivedCallTimesMatcher struct {
+	Method string
+	Times  int
+
+	received int
+}
+
+func (m *receivedCallTimesMatcher) Match(actual interface{}) (bool, error) {
+	recorder, err := recorderFromActual(actual)
+	if err != nil {
+		return false, err
+	}
+	m.received = len(recorder.CallsTo(m.Method))
+	return m.received == m.Times, nil
+}
+
+func (m *receivedCallTimesMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(actual, fmt.Sprintf("to have received %d call(s) to %s, but received %d", m.Times, m.Method, m.received))
+}
+
+func (m *receivedCallTimesMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, fmt.Sprintf("not to have received %d call(s) to %s", m.Times, m.Method))
+}
+
+type returnedStatusMatcher struct {
+	Method string
+	Status types.GomegaMatcher
+
+	lastCall *Call
+}
+
+func (m *returnedStatusMatcher) Match(actual interface{}) (bool, error) {
+	recorder, err := recorderFromActual(actual)
+	if err != nil {
+		return false, err
+	}
+	m.lastCall = nil
+	for _, call := range recorder.CallsTo(m.Method) {
+		call := call
+		success, err := m.Status.Match(call.Status)
+		if err != nil {
+			return false, err
+		}
+		if success {
+			return true, nil
+		}
+		m.lastCall = &call
+	}
+	return false, nil
+}
+
+func (m *returnedStatusMatcher) FailureMessage(actual interface{}) string {
+	if m.lastCall == nil {
+		return format.Message(actual, "to have received a call to "+m.Method)
+	}
+	return format.Message(actual, "to have returned a matching status from "+m.Method) +
+		"\nThe last status did not match:\n" + format.IndentString(m.Status.FailureMessage(m.lastCall.Status), 1)
+}
+
+func (m *returnedStatusMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "not to have returned a matching status from "+m.Method)
+}
@@ -0,0 +1,89 @@
+package grpctest
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	pb "google.golang.org/grpc/examples/features/proto/echo"
+
+	grpcmatchers "github.com/jamillosantos/gomega-grpc"
+)
+
+var _ = Describe("Recorder matchers", func() {
+	const method = "/grpc.examples.echo.Echo/UnaryEcho"
+
+	recorder := NewRecorder()
+	server := Ginkgo(registerEcho, WithRecorder(recorder))
+
+	BeforeEach(func() {
+		recorder.Reset()
+		client := pb.NewEchoClient(server.Conn())
+		_, err := client.UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.UnaryEcho(context.Background(), &pb.EchoRequest{Message: "fail"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("HaveReceivedCall", func() {
+		It("should match a call to the method", func() {
+			Expect(recorder).To(HaveReceivedCall(method))
+			Expect(recorder).ToNot(HaveReceivedCall("/grpc.examples.echo.Echo/ServerStreamingEcho"))
+		})
+
+		It("should match the requests", func() {
+			Expect(recorder).To(HaveReceivedCall(method, grpcmatchers.ProtoEqual(&pb.EchoRequest{Message: "hello"})))
+			Expect(recorder).ToNot(HaveReceivedCall(method, grpcmatchers.ProtoEqual(&pb.EchoRequest{Message: "bye"})))
+		})
+
+		It("should describe the last request that did not match", func() {
+			matcher := HaveReceivedCall(method, grpcmatchers.ProtoEqual(&pb.EchoRequest{Message: "bye"}))
+			Expect(matcher.Match(recorder)).To(BeFalse())
+			message := matcher.FailureMessage(recorder)
+			Expect(message).To(ContainSubstring("to have received a call to " + method + " with a matching request"))
+			Expect(message).To(ContainSubstring("The last request did not match:"))
+			Expect(message).To(ContainSubstring(`message: "bye" -> "fail"`))
+		})
+
+		It("should fail when the actual value is not a Recorder", func() {
+			_, err := HaveReceivedCall(method).Match("recorder")
+			Expect(err).To(MatchError(errExpectedRecorder))
+		})
+	})
+
+	Describe("HaveReceivedCallTimes", func() {
+		It("should match the number of calls", func() {
+			Expect(recorder).To(HaveReceivedCallTimes(method, 2))
+			Expect(recorder).ToNot(HaveReceivedCallTimes(method, 1))
+		})
+
+		It("should describe the number of calls received", func() {
+			matcher := HaveReceivedCallTimes(method, 3)
+			Expect(matcher.Match(recorder)).To(BeFalse())
+			Expect(matcher.FailureMessage(recorder)).To(ContainSubstring("to have received 3 call(s) to " + method + ", but received 2"))
+		})
+	})
+
+	Describe("HaveReturnedStatus", func() {
+		It("should match the statuses of the calls", func() {
+			Expect(recorder).To(HaveReturnedStatus(method, grpcmatchers.HaveStatusCode(Equal(codes.InvalidArgument))))
+			Expect(recorder).To(HaveReturnedStatus(method, grpcmatchers.HaveStatusCode(Equal(codes.OK))))
+			Expect(recorder).ToNot(HaveReturnedStatus(method, grpcmatchers.HaveStatusCode(Equal(codes.Internal))))
+		})
+
+		It("should describe the last status that did not match", func() {
+			matcher := HaveReturnedStatus(method, grpcmatchers.HaveStatusCode(Equal(codes.Internal)))
+			Expect(matcher.Match(recorder)).To(BeFalse())
+			message := matcher.FailureMessage(recorder)
+			Expect(message).To(ContainSubstring("to have returned a matching status from " + method))
+			Expect(message).To(ContainSubstring("The last status did not match:"))
+		})
+
+		It("should not match methods without calls", func() {
+			matcher := HaveReturnedStatus("/grpc.examples.echo.Echo/ServerStreamingEcho", grpcmatchers.HaveStatusCode(Equal(codes.OK)))
+			Expect(matcher.Match(recorder)).To(BeFalse())
+			Expect(matcher.FailureMessage(recorder)).To(ContainSubstring("to have received a call to /grpc.examples.echo.Echo/ServerStreamingEcho"))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package grpctest
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	pb "google.golang.org/grpc/examples/features/proto/echo"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Recorder", func() {
+	recorder := NewRecorder()
+	server := Ginkgo(registerEcho, WithRecorder(recorder))
+
+	var client pb.EchoClient
+
+	BeforeEach(func() {
+		recorder.Reset()
+		client = pb.NewEchoClient(server.Conn())
+	})
+
+	It("should record the unary calls", func() {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "42")
+		_, err := client.UnaryEcho(ctx, &pb.EchoRequest{Message: "hello"})
+		Expect(err).ToNot(HaveOccurred())
+
+		calls := recorder.Calls()
+		Expect(calls).To(HaveLen(1))
+		Expect(calls[0].Method).To(Equal("/grpc.examples.echo.Echo/UnaryEcho"))
+		Expect(calls[0].Metadata.Get("x-request-id")).To(ConsistOf("42"))
+		Expect(proto.Equal(calls[0].Requests[0], &pb.EchoRequest{Message: "hello"})).To(BeTrue())
+		Expect(proto.Equal(calls[0].Responses[0], &pb.EchoResponse{Message: "hello"})).To(BeTrue())
+		Expect(calls[0].Status.Code()).To(Equal(codes.OK))
+	})
+
+	It("should record the failed unary calls without responses", func() {
+		_, err := client.UnaryEcho(context.Background(), &pb.EchoRequest{Message: "fail"})
+		Expect(err).To(HaveOccurred())
+
+		calls := recorder.Calls()
+		Expect(calls).To(HaveLen(1))
+		Expect(calls[0].Responses).To(BeEmpty())
+		Expect(calls[0].Status.Code()).To(Equal(codes.InvalidArgument))
+		Expect(calls[0].Status.Message()).To(Equal("invalid message"))
+	})
+
+	It("should record the messages of the streams", func() {
+		stream, err := client.BidirectionalStreamingEcho(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		for _, message := range []string{"a", "b"} {
+			Expect(stream.Send(&pb.EchoRequest{Message: message})).To(Succeed())
+			_, err = stream.Recv()
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(stream.CloseSend()).To(Succeed())
+		_, err = stream.Recv()
+		Expect(err).To(HaveOccurred())
+
+		calls := recorder.CallsTo("/grpc.examples.echo.Echo/BidirectionalStreamingEcho")
+		Expect(calls).To(HaveLen(1))
+		Expect(calls[0].Requests).To(HaveLen(2))
+		Expect(calls[0].Responses).To(HaveLen(2))
+		Expect(calls[0].Status.Code()).To(Equal(codes.OK))
+	})
+
+	It("should render the calls on failure messages", func() {
+		_, err := client.UnaryEcho(context.Background(), &pb.EchoRequest{Message: "fail"})
+		Expect(err).To(HaveOccurred())
+
+		Expect(recorder.GomegaString()).To(Equal(`/grpc.examples.echo.Echo/UnaryEcho: InvalidArgument (3) "invalid message"
+    request: {"message":"fail"}`))
+	})
+
+	It("should render no calls", func() {
+		Expect(recorder.GomegaString()).To(Equal("<no calls>"))
+	})
+})
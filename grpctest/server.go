@@ -0,0 +1,228 @@
+// Package grpctest runs gRPC services in-process, over a bufconn listener, so the gomega-grpc matchers can be used
+// against a real transport without any network.
+package grpctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// DefaultBufferSize is the size, in bytes, of the bufconn listener buffer.
+	DefaultBufferSize = 1024 * 1024
+	// DefaultDialTimeout is how long NewServer waits for the client connection to be ready.
+	DefaultDialTimeout = 5 * time.Second
+	// DefaultShutdownTimeout is how long Close waits for the open streams to finish and for the server to stop
+	// gracefully.
+	DefaultShutdownTimeout = time.Second
+)
+
+var (
+	errServerClosed    = errors.New("server already closed")
+	errLeakedStreams   = errors.New("streams left open")
+	errShutdownTimeout = errors.New("server did not stop gracefully")
+)
+
+// Option configures the Server created by NewServer.
+type Option func(*Server)
+
+// WithServerOptions appends options to the ones used to create the grpc.Server (eg: interceptors).
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, opts...)
+	}
+}
+
+// WithDialOptions appends options to the ones used to dial the server (eg: client interceptors).
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(s *Server) {
+		s.dialOptions = append(s.dialOptions, opts...)
+	}
+}
+
+// WithBufferSize sets the size, in bytes, of the bufconn listener buffer. It defaults to DefaultBufferSize.
+func WithBufferSize(size int) Option {
+	return func(s *Server) {
+		s.bufferSize = size
+	}
+}
+
+// WithDialTimeout sets how long NewServer waits for the client connection to be ready. It defaults to
+// DefaultDialTimeout.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.dialTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout sets how long Close waits for the open streams to finish and for the server to stop
+// gracefully. It defaults to DefaultShutdownTimeout.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// Server is a grpc.Server listening on a bufconn listener, with a client connection dialed to it.
+//
+// The server tracks its open streams, so Close can report the streams that tests forgot to finish.
+type Server struct {
+	// Conn is the client connection to the server, ready to be given to the generated clients.
+	Conn *grpc.ClientConn
+
+	serverOptions   []grpc.ServerOption
+	dialOptions     []grpc.DialOption
+	bufferSize      int
+	dialTimeout     time.Duration
+	shutdownTimeout time.Duration
+
+	server   *grpc.Server
+	listener *bufconn.Listener
+
+	mu         sync.Mutex
+	streams    map[uint64]string
+	lastStream uint64
+	closed     bool
+}
+
+// NewServer creates a grpc.Server, calls register with it so the services can be registered, starts serving on a
+// bufconn listener and dials it. The returned Server has a ready client connection:
+//
+//	server, err := grpctest.NewServer(func(s *grpc.Server) {
+//		pb.RegisterBooksServer(s, &booksServer{})
+//	})
+//	client := pb.NewBooksClient(server.Conn)
+//
+// Close must be called to shut the server down. See Start and Ginkgo for helpers that do that automatically.
+func NewServer(register func(*grpc.Server), opts ...Option) (*Server, error) {
+	s := &Server{
+		bufferSize:      DefaultBufferSize,
+		dialTimeout:     DefaultDialTimeout,
+		shutdownTimeout: DefaultShutdownTimeout,
+		streams:         make(map[uint64]string),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// The stream tracker is the outermost interceptor, so it sees the streams for as long as they are open.
+	serverOptions := append([]grpc.ServerOption{grpc.ChainStreamInterceptor(s.trackStream)}, s.serverOptions...)
+	s.server = grpc.NewServer(serverOptions...)
+	register(s.server)
+
+	s.listener = bufconn.Listen(s.bufferSize)
+	go func() {
+		_ = s.server.Serve(s.listener)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.dialTimeout)
+	defer cancel()
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	}, s.dialOptions...)
+	conn, err := grpc.DialContext(ctx, "bufnet", dialOptions...)
+	if err != nil {
+		s.server.Stop()
+		return nil, fmt.Errorf("dialing the bufconn listener: %w", err)
+	}
+	s.Conn = conn
+	return s, nil
+}
+
+// GRPCServer returns the underlying grpc.Server.
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.server
+}
+
+func (s *Server) trackStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	s.mu.Lock()
+	s.lastStream++
+	id := s.lastStream
+	s.streams[id] = info.FullMethod
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, id)
+		s.mu.Unlock()
+	}()
+	return handler(srv, ss)
+}
+
+// openStreams returns the methods of the streams that are still open, sorted.
+func (s *Server) openStreams() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := make([]string, 0, len(s.streams))
+	for _, method := range s.streams {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// waitStreams waits, up to the deadline, for all the open streams to finish. It returns the methods of the streams
+// still open.
+func (s *Server) waitStreams(deadline time.Time) []string {
+	for {
+		open := s.openStreams()
+		if len(open) == 0 || !time.Now().Before(deadline) {
+			return open
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Close shuts the server down. It waits for the open streams to finish, closes the client connection and stops the
+// server gracefully, forcing it to stop if it takes longer than the shutdown timeout.
+//
+// Close fails if any stream was still open when it was called (eg: a client stream that was never closed or a server
+// stream that was not drained) or if the server did not stop gracefully.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errServerClosed
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	deadline := time.Now().Add(s.shutdownTimeout)
+	leaked := s.waitStreams(deadline)
+	_ = s.Conn.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	var err error
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.server.Stop()
+		<-stopped
+		err = fmt.Errorf("%w in %s", errShutdownTimeout, s.shutdownTimeout)
+	}
+
+	if len(leaked) > 0 {
+		return fmt.Errorf("%w: %s", errLeakedStreams, strings.Join(leaked, ", "))
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package grpctest
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	pb "google.golang.org/grpc/examples/features/proto/echo"
+
+	grpcmatchers "github.com/jamillosantos/gomega-grpc"
+)
+
+var _ = Describe("NewServer", func() {
+	It("should serve the registered services over bufconn", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(server.Close()).To(Succeed())
+		}()
+
+		resp, err := pb.NewEchoClient(server.Conn).UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.GetMessage()).To(Equal("hello"))
+	})
+
+	It("should serve the server streams", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(server.Close()).To(Succeed())
+		}()
+
+		stream, err := pb.NewEchoClient(server.Conn).ServerStreamingEcho(context.Background(), &pb.EchoRequest{Message: "hello world"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stream).To(grpcmatchers.ReceiveWithin(time.Second,
+			grpcmatchers.ReceiveProtosInOrder(&pb.EchoResponse{Message: "hello"}, &pb.EchoResponse{Message: "world"}),
+			grpcmatchers.EndWithStatus(grpcmatchers.HaveStatusCode(Equal(codes.OK))),
+		))
+	})
+
+	It("should serve the bidirectional streams", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(server.Close()).To(Succeed())
+		}()
+
+		stream, err := pb.NewEchoClient(server.Conn).BidirectionalStreamingEcho(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		grpcmatchers.Converse(stream).
+			Within(time.Second).
+			Send(&pb.EchoRequest{Message: "hello"}).
+			ExpectRecv(grpcmatchers.ProtoEqual(&pb.EchoResponse{Message: "hello"})).
+			Send(&pb.EchoRequest{Message: "fail"}).
+			ExpectStatus(grpcmatchers.HaveStatusCode(Equal(codes.InvalidArgument)))
+	})
+
+	It("should stop the bidirectional streams canceled on timeout", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(server.Close()).To(Succeed())
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := pb.NewEchoClient(server.Conn).BidirectionalStreamingEcho(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		var failures []string
+		g := NewGomega(func(message string, _ ...int) {
+			failures = append(failures, message)
+		})
+		grpcmatchers.ConverseWith(g, grpcmatchers.CancelOnTimeout(stream, cancel)).
+			Within(50 * time.Millisecond).
+			ExpectRecv(grpcmatchers.ProtoEqual(&pb.EchoResponse{Message: "hello"}))
+		Expect(failures).To(ConsistOf(ContainSubstring("receiving: timed out")))
+		Expect(ctx.Err()).To(MatchError(context.Canceled))
+	})
+
+	It("should apply the server options", func() {
+		var methods []string
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			return handler(ctx, req)
+		}
+		server, err := NewServer(registerEcho, WithServerOptions(grpc.ChainUnaryInterceptor(interceptor)))
+		Expect(err).ToNot(HaveOccurred())
+		defer func() {
+			Expect(server.Close()).To(Succeed())
+		}()
+
+		_, err = pb.NewEchoClient(server.Conn).UnaryEcho(context.Background(), &pb.EchoRequest{Message: "hello"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(methods).To(ConsistOf("/grpc.examples.echo.Echo/UnaryEcho"))
+	})
+
+	It("should fail to close twice", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(server.Close()).To(Succeed())
+		Expect(server.Close()).To(MatchError(errServerClosed))
+	})
+})
+
+var _ = Describe("Server.Close", func() {
+	It("should wait for the streams that are finishing", func() {
+		server, err := NewServer(registerEcho)
+		Expect(err).ToNot(HaveOccurred())
+
+		stream, err := pb.NewEchoClient(server.Conn).BidirectionalStreamingEcho(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stream.CloseSend()).To(Succeed())
+
+		Expect(server.Close()).To(Succeed())
+	})
+
+	It("should report the streams left open", func() {
+		server, err := NewServer(registerEcho, WithShutdownTimeout(50*time.Millisecond))
+		Expect(err).ToNot(HaveOccurred())
+
+		stream, err := pb.NewEchoClient(server.Conn).BidirectionalStreamingEcho(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stream.Send(&pb.EchoRequest{Message: "hello"})).To(Succeed())
+		_, err = stream.Recv()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = server.Close()
+		Expect(err).To(MatchError(errLeakedStreams))
+		Expect(err).To(MatchError(ContainSubstring("/grpc.examples.echo.Echo/BidirectionalStreamingEcho")))
+	})
+})
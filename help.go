@@ -0,0 +1,25 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveHelpLink matches the description and url of any of the links of the errdetails.Help in the *status.Status
+// details. A nil matcher matches any value.
+//
+//	Expect(err).To(HaveHelpLink(ContainSubstring("quota"), HavePrefix("https://")))
+func HaveHelpLink(description, url types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailItemsMatcher[*errdetails.Help, *errdetails.Help_Link]{
+		Description: "a help link",
+		Items: func(help *errdetails.Help) []*errdetails.Help_Link {
+			return help.GetLinks()
+		},
+		Props: []matchersimpl.DetailProp[*errdetails.Help_Link]{
+			{Name: "description", PropMap: func(l *errdetails.Help_Link) interface{} { return l.GetDescription() }, Matcher: description},
+			{Name: "url", PropMap: func(l *errdetails.Help_Link) interface{} { return l.GetUrl() }, Matcher: url},
+		},
+	})
+}
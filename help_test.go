@@ -0,0 +1,43 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Help", func() {
+	st, err := status.New(codes.ResourceExhausted, "random error").WithDetails(&errdetails.Help{
+		Links: []*errdetails.Help_Link{
+			{Description: "Quota documentation", Url: "https://example.com/quota"},
+			{Description: "Request a quota increase", Url: "https://example.com/quota/increase"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveHelpLink", func() {
+		It("should match a link by description and url", func() {
+			Expect(err).To(HaveHelpLink(Equal("Request a quota increase"), HaveSuffix("/increase")))
+		})
+
+		It("should match any value with a nil matcher", func() {
+			Expect(err).To(HaveHelpLink(nil, Equal("https://example.com/quota")))
+		})
+
+		It("should not match properties of different links", func() {
+			Expect(err).ToNot(HaveHelpLink(Equal("Quota documentation"), HaveSuffix("/increase")))
+		})
+	})
+})
+
+func ExampleHaveHelpLink() {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(&errdetails.Help{
+		Links: []*errdetails.Help_Link{{Description: "Quota documentation", Url: "https://example.com/quota"}},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveHelpLink(ContainSubstring("Quota"), HavePrefix("https://")))
+}
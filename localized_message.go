@@ -0,0 +1,24 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveLocalizedMessage finds the errdetails.LocalizedMessage of the given locale in the *status.Status details and
+// matches its message against the given matcher. Statuses may carry a LocalizedMessage for each locale.
+//
+//	Expect(err).To(HaveLocalizedMessage("pt-BR", Equal("Livro não encontrado")))
+func HaveLocalizedMessage(locale string, matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailItemsMatcher[*errdetails.LocalizedMessage, *errdetails.LocalizedMessage]{
+		Description: "a localized message",
+		Items:       detailItself[*errdetails.LocalizedMessage],
+		Props: []matchersimpl.DetailProp[*errdetails.LocalizedMessage]{
+			{Name: "locale", PropMap: func(l *errdetails.LocalizedMessage) interface{} { return l.GetLocale() }, Matcher: gomega.Equal(locale)},
+			{Name: "message", PropMap: func(l *errdetails.LocalizedMessage) interface{} { return l.GetMessage() }, Matcher: matcher},
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("LocalizedMessage", func() {
+	st, err := status.New(codes.NotFound, "random error").WithDetails(
+		&errdetails.LocalizedMessage{Locale: "en-US", Message: "Book not found"},
+		&errdetails.LocalizedMessage{Locale: "pt-BR", Message: "Livro não encontrado"},
+	)
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveLocalizedMessage", func() {
+		It("should match the message of each locale", func() {
+			Expect(err).To(HaveLocalizedMessage("en-US", Equal("Book not found")))
+			Expect(err).To(HaveLocalizedMessage("pt-BR", Equal("Livro não encontrado")))
+		})
+
+		It("should not match the message of another locale", func() {
+			Expect(err).ToNot(HaveLocalizedMessage("en-US", Equal("Livro não encontrado")))
+		})
+
+		It("should not match a missing locale", func() {
+			Expect(err).ToNot(HaveLocalizedMessage("es-ES", Not(BeEmpty())))
+		})
+	})
+})
+
+func ExampleHaveLocalizedMessage() {
+	st, err := status.New(codes.NotFound, "message").WithDetails(&errdetails.LocalizedMessage{
+		Locale:  "pt-BR",
+		Message: "Livro não encontrado",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveLocalizedMessage("pt-BR", ContainSubstring("não encontrado")))
+}
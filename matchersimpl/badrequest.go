@@ -2,9 +2,8 @@ package matchersimpl
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
 )
@@ -42,7 +41,7 @@ func (m *GRPCBadRequestMatcher) Match(st *status.Status) (bool, error) {
 func (m *GRPCBadRequestMatcher) FailureMessage(st *status.Status) string {
 	errInfo, ok := findBadRequest(st)
 	if !ok {
-		return format.Message(st, "does not have an *errdetails.BadRequest in the details")
+		return detailNotFoundMessage[*errdetails.BadRequest](st)
 	}
 	return m.badRequestMatcher.FailureMessage(errInfo)
 }
@@ -50,37 +49,45 @@ func (m *GRPCBadRequestMatcher) FailureMessage(st *status.Status) string {
 func (m *GRPCBadRequestMatcher) NegatedFailureMessage(st *status.Status) string {
 	errInfo, ok := findBadRequest(st)
 	if !ok {
-		return format.Message(st, "does not have an *errdetails.BadRequest in the details")
+		return detailNotFoundMessage[*errdetails.BadRequest](st)
 	}
 	return m.badRequestMatcher.NegatedFailureMessage(errInfo)
 }
 
 // GRPCBadRequestFieldViolation tries matching the matching the Field of a given errdetails.BadRequest_FieldViolation.
 // If the given Description is not empty, this will only match if both Field and Description match.
+//
+// Deprecated: use GRPCFieldViolationMatcher, which accepts matchers for the Field and the Description.
 type GRPCBadRequestFieldViolation struct {
 	Field       string
 	Description string
 }
 
-func (m *GRPCBadRequestFieldViolation) Match(actual *errdetails.BadRequest) (bool, error) {
-	for _, fv := range actual.GetFieldViolations() {
-		if m.matchesFV(fv) {
-			return true, nil
-		}
+// fieldViolationMatcher returns the GRPCFieldViolationMatcher equivalent to m. An empty Description matches any
+// description.
+func (m *GRPCBadRequestFieldViolation) fieldViolationMatcher() *GRPCFieldViolationMatcher {
+	matcher := &GRPCFieldViolationMatcher{Field: gomega.Equal(m.Field)}
+	if m.Description != "" {
+		matcher.Description = gomega.Equal(m.Description)
 	}
-	return false, nil
+	return matcher
+}
+
+func (m *GRPCBadRequestFieldViolation) Match(actual *errdetails.BadRequest) (bool, error) {
+	return m.fieldViolationMatcher().Match(actual)
 }
 
 func (m *GRPCBadRequestFieldViolation) matchesFV(fv *errdetails.BadRequest_FieldViolation) bool {
-	return fv.GetField() == m.Field && (m.Description == "" || fv.GetDescription() == m.Description)
+	success, err := m.Match(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{fv}})
+	return err == nil && success
 }
 
 func (m *GRPCBadRequestFieldViolation) FailureMessage(actual *errdetails.BadRequest) string {
-	return format.Message(actual, "does not have", fmt.Sprintf("[%s: %s]", m.Field, m.Description))
+	return m.fieldViolationMatcher().FailureMessage(actual)
 }
 
 func (m *GRPCBadRequestFieldViolation) NegatedFailureMessage(actual *errdetails.BadRequest) string {
-	return format.Message(actual, "have", fmt.Sprintf("[%s: %s]", m.Field, m.Description))
+	return m.fieldViolationMatcher().NegatedFailureMessage(actual)
 }
 
 // findBadRequest walks through the error details of the given st trying to find a errdetails.BadRequest instance. If it
@@ -88,11 +95,5 @@ func (m *GRPCBadRequestFieldViolation) NegatedFailureMessage(actual *errdetails.
 //
 // Otherwise, it returns false.
 func findBadRequest(st *status.Status) (*errdetails.BadRequest, bool) {
-	for _, d := range st.Details() {
-		errInfo, ok := d.(*errdetails.BadRequest)
-		if ok {
-			return errInfo, true
-		}
-	}
-	return nil, false
+	return findStatusDetail[*errdetails.BadRequest](st)
 }
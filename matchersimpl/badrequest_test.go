@@ -205,3 +205,10 @@ func TestGRPCBadRequestFieldViolation_matchesFV(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCBadRequestFieldViolation_FailureMessage(t *testing.T) {
+	m := &GRPCBadRequestFieldViolation{Field: "field"}
+	actual := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "feld"}}}
+	assert.Contains(t, m.FailureMessage(actual), "to have a field violation with")
+	assert.Contains(t, m.NegatedFailureMessage(actual), "not to have a field violation with")
+}
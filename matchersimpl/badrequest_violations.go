@@ -0,0 +1,159 @@
+package matchersimpl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers/support/goraph/bipartitegraph"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// GRPCFieldViolationMatcher implements a BadRequestMatcher that succeeds if any of the field violations has its Field
+// and Description matched. A nil matcher matches any value.
+//
+// When the Field matcher is an Equal matcher and no violation matches, the failure message suggests the closest
+// field name found in the violations.
+type GRPCFieldViolationMatcher struct {
+	Field       types.GomegaMatcher
+	Description types.GomegaMatcher
+}
+
+func (m *GRPCFieldViolationMatcher) props() []DetailProp[*errdetails.BadRequest_FieldViolation] {
+	return []DetailProp[*errdetails.BadRequest_FieldViolation]{
+		{Name: "field", PropMap: func(v *errdetails.BadRequest_FieldViolation) interface{} { return v.GetField() }, Matcher: m.Field},
+		{Name: "description", PropMap: func(v *errdetails.BadRequest_FieldViolation) interface{} { return v.GetDescription() }, Matcher: m.Description},
+	}
+}
+
+func (m *GRPCFieldViolationMatcher) Match(actual *errdetails.BadRequest) (bool, error) {
+	return matchAnyItem(actual.GetFieldViolations(), m.props())
+}
+
+func (m *GRPCFieldViolationMatcher) FailureMessage(actual *errdetails.BadRequest) string {
+	return format.Message(describeFieldViolations(actual.GetFieldViolations()), "to have a field violation with") + "\n" +
+		describeDetailProps(m.props()) + m.suggestion(actual.GetFieldViolations())
+}
+
+func (m *GRPCFieldViolationMatcher) NegatedFailureMessage(actual *errdetails.BadRequest) string {
+	return format.Message(describeFieldViolations(actual.GetFieldViolations()), "not to have a field violation with") + "\n" +
+		describeDetailProps(m.props())
+}
+
+// suggestion returns a line suggesting the closest field name of the given violations, if the expected field looks
+// mistyped. Otherwise, it returns an empty string.
+func (m *GRPCFieldViolationMatcher) suggestion(violations []*errdetails.BadRequest_FieldViolation) string {
+	field, ok := expectedString(m.Field)
+	if !ok {
+		return ""
+	}
+	fields := make([]string, len(violations))
+	for i, violation := range violations {
+		fields[i] = violation.GetField()
+	}
+	closest, ok := closestName(field, fields)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("\nThere is no field violation for %q, did you mean %q?", field, closest)
+}
+
+// GRPCExactFieldViolationsMatcher implements a BadRequestMatcher that succeeds if each field violation is matched by
+// exactly one of the Violations, regardless of the order, and no violation is left unmatched on either side.
+type GRPCExactFieldViolationsMatcher struct {
+	Violations []*GRPCFieldViolationMatcher
+
+	// missing holds the indexes of the Violations that were not matched by the last Match call.
+	missing []int
+	extra   []*errdetails.BadRequest_FieldViolation
+}
+
+func (m *GRPCExactFieldViolationsMatcher) Match(actual *errdetails.BadRequest) (bool, error) {
+	m.missing, m.extra = nil, nil
+
+	violations := make([]interface{}, len(actual.GetFieldViolations()))
+	for i, violation := range actual.GetFieldViolations() {
+		violations[i] = violation
+	}
+	expected := make([]interface{}, len(m.Violations))
+	for i, violation := range m.Violations {
+		expected[i] = violation
+	}
+
+	graph, err := bipartitegraph.NewBipartiteGraph(violations, expected, func(violation, matcher interface{}) (bool, error) {
+		return matchItem(violation.(*errdetails.BadRequest_FieldViolation), matcher.(*GRPCFieldViolationMatcher).props())
+	})
+	if err != nil {
+		return false, err
+	}
+	edges := graph.LargestMatching()
+	if len(edges) == len(violations) && len(edges) == len(expected) {
+		return true, nil
+	}
+	extra, missing := graph.FreeLeftRight(edges)
+	for _, violation := range extra {
+		m.extra = append(m.extra, violation.(*errdetails.BadRequest_FieldViolation))
+	}
+	for _, matcher := range missing {
+		for i, violation := range m.Violations {
+			if violation == matcher {
+				m.missing = append(m.missing, i)
+				break
+			}
+		}
+	}
+	return false, nil
+}
+
+func (m *GRPCExactFieldViolationsMatcher) FailureMessage(actual *errdetails.BadRequest) string {
+	var sb strings.Builder
+	sb.WriteString(format.Message(describeFieldViolations(actual.GetFieldViolations()), "to have exactly the field violations"))
+	sb.WriteString("\n")
+	sb.WriteString(m.describeViolations(nil))
+	if len(m.missing) > 0 {
+		sb.WriteString("\nthe missing field violations were\n")
+		sb.WriteString(m.describeViolations(m.missing))
+		for _, i := range m.missing {
+			sb.WriteString(m.Violations[i].suggestion(m.extra))
+		}
+	}
+	if len(m.extra) > 0 {
+		sb.WriteString("\nthe extra field violations were\n")
+		sb.WriteString(format.IndentString(describeFieldViolations(m.extra), 1))
+	}
+	return sb.String()
+}
+
+func (m *GRPCExactFieldViolationsMatcher) NegatedFailureMessage(actual *errdetails.BadRequest) string {
+	return format.Message(describeFieldViolations(actual.GetFieldViolations()), "not to have exactly the field violations") + "\n" +
+		m.describeViolations(nil)
+}
+
+// describeViolations renders the properties of the Violations at the given indexes (or all of them, if indexes is
+// nil), labeled by their indexes.
+func (m *GRPCExactFieldViolationsMatcher) describeViolations(indexes []int) string {
+	if indexes == nil {
+		indexes = make([]int, len(m.Violations))
+		for i := range indexes {
+			indexes[i] = i
+		}
+	}
+	descriptions := make([]string, len(indexes))
+	for i, index := range indexes {
+		descriptions[i] = fmt.Sprintf("%s[%d]\n%s", format.Indent, index, format.IndentString(describeDetailProps(m.Violations[index].props()), 1))
+	}
+	return strings.Join(descriptions, "\n")
+}
+
+// describeFieldViolations renders the violations, one per line, as JSON.
+func describeFieldViolations(violations []*errdetails.BadRequest_FieldViolation) string {
+	if len(violations) == 0 {
+		return "<no field violations>"
+	}
+	lines := make([]string, len(violations))
+	for i, violation := range violations {
+		lines[i] = formatMessage(violation)
+	}
+	return strings.Join(lines, "\n")
+}
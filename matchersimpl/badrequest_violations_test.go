@@ -0,0 +1,125 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+var badRequestWithViolations = &errdetails.BadRequest{
+	FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "book.title", Description: "must not be empty"},
+		{Field: "book.isbn"},
+	},
+}
+
+func TestGRPCFieldViolationMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name        string
+		matcher     *GRPCFieldViolationMatcher
+		wantSuccess bool
+	}{
+		{"should match by field", &GRPCFieldViolationMatcher{Field: gomega.Equal("book.isbn")}, true},
+		{"should match by field and description", &GRPCFieldViolationMatcher{Field: gomega.Equal("book.title"), Description: gomega.ContainSubstring("empty")}, true},
+		{"should match an empty description", &GRPCFieldViolationMatcher{Field: gomega.Equal("book.isbn"), Description: gomega.BeEmpty()}, true},
+		{"should not match an empty description", &GRPCFieldViolationMatcher{Field: gomega.Equal("book.title"), Description: gomega.BeEmpty()}, false},
+		{"should not match a non existing field", &GRPCFieldViolationMatcher{Field: gomega.Equal("book.author")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(badRequestWithViolations)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+}
+
+func TestGRPCFieldViolationMatcher_FailureMessage(t *testing.T) {
+	t.Run("should list all the violations", func(t *testing.T) {
+		gotMessage := (&GRPCFieldViolationMatcher{Field: gomega.Equal("book.author")}).FailureMessage(badRequestWithViolations)
+		assert.Contains(t, gotMessage, `{"field":"book.title","description":"must not be empty"}`)
+		assert.Contains(t, gotMessage, `{"field":"book.isbn"}`)
+		assert.Contains(t, gotMessage, "to have a field violation with")
+		assert.NotContains(t, gotMessage, "did you mean")
+	})
+
+	t.Run("should suggest the closest field", func(t *testing.T) {
+		gotMessage := (&GRPCFieldViolationMatcher{Field: gomega.Equal("book.titel")}).FailureMessage(badRequestWithViolations)
+		assert.Contains(t, gotMessage, `There is no field violation for "book.titel", did you mean "book.title"?`)
+	})
+
+	t.Run("should not suggest when the field exists", func(t *testing.T) {
+		gotMessage := (&GRPCFieldViolationMatcher{Field: gomega.Equal("book.title"), Description: gomega.BeEmpty()}).FailureMessage(badRequestWithViolations)
+		assert.NotContains(t, gotMessage, "did you mean")
+	})
+}
+
+func TestGRPCFieldViolationMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCFieldViolationMatcher{Field: gomega.Equal("book.title")}).NegatedFailureMessage(badRequestWithViolations)
+	assert.Contains(t, gotMessage, "not to have a field violation with")
+}
+
+func TestGRPCExactFieldViolationsMatcher_Match(t *testing.T) {
+	title := &GRPCFieldViolationMatcher{Field: gomega.Equal("book.title")}
+	isbn := &GRPCFieldViolationMatcher{Field: gomega.Equal("book.isbn")}
+	author := &GRPCFieldViolationMatcher{Field: gomega.Equal("book.author")}
+	anyField := &GRPCFieldViolationMatcher{}
+
+	tests := []struct {
+		name        string
+		violations  []*GRPCFieldViolationMatcher
+		wantSuccess bool
+		wantMissing []int
+		wantExtra   []string
+	}{
+		{"should match all the violations", []*GRPCFieldViolationMatcher{isbn, title}, true, nil, nil},
+		{"should match overlapping matchers", []*GRPCFieldViolationMatcher{anyField, title}, true, nil, nil},
+		{"should report the missing violations", []*GRPCFieldViolationMatcher{title, author, isbn}, false, []int{1}, nil},
+		{"should report the extra violations", []*GRPCFieldViolationMatcher{title}, false, nil, []string{"book.isbn"}},
+		{"should report both", []*GRPCFieldViolationMatcher{author, title}, false, []int{0}, []string{"book.isbn"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := &GRPCExactFieldViolationsMatcher{Violations: tt.violations}
+			gotSuccess, err := matcher.Match(badRequestWithViolations)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+			assert.Equal(t, tt.wantMissing, matcher.missing)
+			var gotExtra []string
+			for _, violation := range matcher.extra {
+				gotExtra = append(gotExtra, violation.GetField())
+			}
+			assert.Equal(t, tt.wantExtra, gotExtra)
+		})
+	}
+
+	t.Run("should match no violations", func(t *testing.T) {
+		gotSuccess, err := (&GRPCExactFieldViolationsMatcher{}).Match(&errdetails.BadRequest{})
+		assert.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+}
+
+func TestGRPCExactFieldViolationsMatcher_FailureMessage(t *testing.T) {
+	matcher := &GRPCExactFieldViolationsMatcher{Violations: []*GRPCFieldViolationMatcher{
+		{Field: gomega.Equal("book.title")},
+		{Field: gomega.Equal("book.ibsn")},
+	}}
+	gotSuccess, err := matcher.Match(badRequestWithViolations)
+	require.NoError(t, err)
+	require.False(t, gotSuccess)
+
+	gotMessage := matcher.FailureMessage(badRequestWithViolations)
+	assert.Contains(t, gotMessage, "to have exactly the field violations")
+	assert.Contains(t, gotMessage, "the missing field violations were\n    [1]\n")
+	assert.Contains(t, gotMessage, "the extra field violations were\n    {\"field\":\"book.isbn\"}")
+	assert.Contains(t, gotMessage, `did you mean "book.isbn"?`)
+}
+
+func TestGRPCExactFieldViolationsMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCExactFieldViolationsMatcher{}).NegatedFailureMessage(badRequestWithViolations)
+	assert.Contains(t, gotMessage, "not to have exactly the field violations")
+}
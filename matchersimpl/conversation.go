@@ -0,0 +1,287 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errExpectedBidiStream = errors.New("the given object is not a bidirectional stream with Send, Recv and CloseSend methods")
+	errStepTimeout        = errors.New("timed out")
+)
+
+// closeSender is implemented by the client streams (see grpc.ClientStream).
+type closeSender interface {
+	CloseSend() error
+}
+
+// Conversation runs a script of steps on a bidirectional stream, one step per method call, failing on the first step
+// that does not succeed. All the steps share the same timeout, counted from the first step, so a hung server fails the
+// test instead of hanging the suite.
+//
+// Failures are reported to the Gomega given to NewConversation, with the step that failed and a transcript of
+// everything sent and received. Once a step fails, the following ones are skipped.
+type Conversation struct {
+	g       gomega.Gomega
+	stream  interface{}
+	cancel  func()
+	timeout time.Duration
+
+	send     reflect.Value
+	recv     func() (interface{}, error)
+	err      error
+	deadline time.Time
+
+	steps      int
+	transcript []string
+	failed     bool
+}
+
+// NewConversation creates a Conversation on the given stream. Streams that do not have the Send, Recv and CloseSend
+// methods of the generated bidirectional client streams fail the first step.
+//
+// The stream can be wrapped by a *CancelableStream, so its context is canceled when a step times out.
+func NewConversation(g gomega.Gomega, stream interface{}, timeout time.Duration) *Conversation {
+	stream, cancel := unwrapCancelableStream(stream)
+	c := &Conversation{
+		g:       g,
+		stream:  stream,
+		cancel:  cancel,
+		timeout: timeout,
+	}
+	recv, err := recvFunc(stream)
+	_, isCloseSender := stream.(closeSender)
+	if err != nil || !isCloseSender {
+		c.err = fmt.Errorf("%w: got %T", errExpectedBidiStream, stream)
+		return c
+	}
+	send := reflect.ValueOf(stream).MethodByName("Send")
+	if !send.IsValid() || send.Type().NumIn() != 1 || send.Type().NumOut() != 1 || send.Type().Out(0) != errorType {
+		c.err = fmt.Errorf("%w: got %T", errExpectedBidiStream, stream)
+		return c
+	}
+	c.recv, c.send = recv, send
+	return c
+}
+
+// Within changes the timeout of the conversation. It must be called before the first step.
+func (c *Conversation) Within(timeout time.Duration) *Conversation {
+	c.timeout = timeout
+	return c
+}
+
+// Send sends the message on the stream.
+func (c *Conversation) Send(m proto.Message) *Conversation {
+	return c.step("send", func() string {
+		value := reflect.ValueOf(m)
+		if !value.IsValid() || !value.Type().AssignableTo(c.send.Type().In(0)) {
+			return fmt.Sprintf("cannot send a %T on a %T", m, c.stream)
+		}
+		var err error
+		if !c.await(func() {
+			err, _ = c.send.Call([]reflect.Value{value})[0].Interface().(error)
+		}) {
+			return c.timeoutMessage("sending")
+		}
+		if err != nil {
+			c.record("failed to send %s: %s", FormatMessage(m, DetailFormatJSON), err)
+			return "failed to send: " + err.Error()
+		}
+		c.record("sent %s", FormatMessage(m, DetailFormatJSON))
+		return ""
+	})
+}
+
+// ExpectRecv receives the next message of the stream and matches it against the given matcher.
+func (c *Conversation) ExpectRecv(matcher types.GomegaMatcher) *Conversation {
+	return c.step("expect recv", func() string {
+		m, st, ok := c.receive()
+		switch {
+		case !ok:
+			return c.timeoutMessage("receiving")
+		case st != nil:
+			return "Expected to receive a message, but the stream ended with " + formatConversationStatus(st)
+		}
+		success, err := matcher.Match(m)
+		if err != nil {
+			return err.Error()
+		}
+		if !success {
+			return matcher.FailureMessage(m)
+		}
+		return ""
+	})
+}
+
+// CloseSend closes the sending direction of the stream.
+func (c *Conversation) CloseSend() *Conversation {
+	return c.step("close send", func() string {
+		var err error
+		if !c.await(func() {
+			err = c.stream.(closeSender).CloseSend()
+		}) {
+			return c.timeoutMessage("closing")
+		}
+		if err != nil {
+			c.record("failed to close send: %s", err)
+			return "failed to close send: " + err.Error()
+		}
+		c.record("closed send")
+		return ""
+	})
+}
+
+// ExpectStatus waits for the stream to end and matches the status it ended with against the given matcher, usually
+// one of the status matchers. Streams ended by io.EOF have the OK status. Receiving a message fails the step.
+func (c *Conversation) ExpectStatus(matcher types.GomegaMatcher) *Conversation {
+	return c.step("expect status", func() string {
+		m, st, ok := c.receive()
+		switch {
+		case !ok:
+			return c.timeoutMessage("waiting for the stream to end")
+		case st == nil:
+			return "Expected the stream to end, but it received " + FormatMessage(m, DetailFormatJSON)
+		}
+		success, err := matcher.Match(st)
+		if err != nil {
+			return err.Error()
+		}
+		if !success {
+			return matcher.FailureMessage(st)
+		}
+		return ""
+	})
+}
+
+// receive receives the next message from the stream. When the stream ends, the status it ended with is returned
+// instead. ok is false when the timeout elapses.
+func (c *Conversation) receive() (m proto.Message, st *status.Status, ok bool) {
+	var (
+		value interface{}
+		err   error
+	)
+	if !c.await(func() {
+		value, err = c.recv()
+	}) {
+		return nil, nil, false
+	}
+	if err != nil {
+		st = status.Convert(err)
+		if errors.Is(err, io.EOF) {
+			st = status.New(codes.OK, "")
+		}
+		c.record("ended with %s", formatConversationStatus(st))
+		return nil, st, true
+	}
+	m, _ = value.(proto.Message)
+	c.record("received %s", FormatMessage(m, DetailFormatJSON))
+	return m, nil, true
+}
+
+// step runs the action, unless a previous step failed, and reports its failure. The action returns the failure
+// message, or an empty string when it succeeds.
+func (c *Conversation) step(name string, action func() string) *Conversation {
+	if c.failed {
+		return c
+	}
+	c.steps++
+	if c.deadline.IsZero() {
+		c.deadline = time.Now().Add(c.timeout)
+	}
+	failure := ""
+	if c.err != nil {
+		failure = c.err.Error()
+	} else {
+		failure = action()
+	}
+	if failure != "" {
+		c.failed = true
+		// The offset skips step and the step method, pointing the failure to the test.
+		c.g.ExpectWithOffset(2, c).To(&conversationStepMatcher{
+			message: fmt.Sprintf("Conversation failed at step %d (%s):\n%s\nTranscript:\n%s",
+				c.steps, name, format.IndentString(failure, 1), c.describeTranscript()),
+		})
+	}
+	return c
+}
+
+// await runs fn until it returns or the deadline of the conversation. It returns false when the deadline is reached.
+//
+// Then, if the stream was given as a *CancelableStream, its context is canceled and await waits, for up to another
+// timeout, for fn to return. Otherwise, fn is left blocked until the stream context is canceled.
+func (c *Conversation) await(fn func()) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	timer := time.NewTimer(time.Until(c.deadline))
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+	}
+	c.record("timed out")
+	if c.cancel != nil {
+		c.cancel()
+		timer.Reset(c.timeout)
+		select {
+		case <-done:
+		case <-timer.C:
+		}
+	}
+	return false
+}
+
+func (c *Conversation) timeoutMessage(action string) string {
+	return fmt.Sprintf("%s: %s after the conversation ran for %s", action, errStepTimeout, c.timeout)
+}
+
+func (c *Conversation) record(entry string, args ...interface{}) {
+	c.transcript = append(c.transcript, fmt.Sprintf(entry, args...))
+}
+
+func (c *Conversation) describeTranscript() string {
+	if len(c.transcript) == 0 {
+		return format.Indent + "<empty>"
+	}
+	lines := make([]string, len(c.transcript))
+	for i, entry := range c.transcript {
+		lines[i] = fmt.Sprintf("%s%d. %s", format.Indent, i+1, entry)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatConversationStatus(st *status.Status) string {
+	return fmt.Sprintf("%s (%d) %q", st.Code(), st.Code(), st.Message())
+}
+
+// conversationStepMatcher reports the failure of a Conversation step through a Gomega assertion, so it is handled by
+// the fail handler of the Gomega like any other failure.
+type conversationStepMatcher struct {
+	message string
+}
+
+func (m *conversationStepMatcher) Match(_ interface{}) (bool, error) {
+	return false, nil
+}
+
+func (m *conversationStepMatcher) FailureMessage(_ interface{}) string {
+	return m.message
+}
+
+func (m *conversationStepMatcher) NegatedFailureMessage(_ interface{}) string {
+	return m.message
+}
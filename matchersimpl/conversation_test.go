@@ -0,0 +1,136 @@
+package matchersimpl
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBidiStream stands for a generated bidirectional client stream that echoes the messages sent. Once the sending
+// direction is closed, it ends with err.
+type fakeBidiStream struct {
+	messages chan *errdetails.ErrorInfo
+	err      error
+}
+
+func newFakeBidiStream(err error) *fakeBidiStream {
+	return &fakeBidiStream{messages: make(chan *errdetails.ErrorInfo, 10), err: err}
+}
+
+func (s *fakeBidiStream) Send(m *errdetails.ErrorInfo) error {
+	s.messages <- m
+	return nil
+}
+
+func (s *fakeBidiStream) Recv() (*errdetails.ErrorInfo, error) {
+	m, ok := <-s.messages
+	if !ok {
+		return nil, s.err
+	}
+	return m, nil
+}
+
+func (s *fakeBidiStream) CloseSend() error {
+	close(s.messages)
+	return nil
+}
+
+// newFailuresGomega returns a Gomega that collects the failure messages.
+func newFailuresGomega() (gomega.Gomega, *[]string) {
+	var failures []string
+	return gomega.NewGomega(func(message string, _ ...int) {
+		failures = append(failures, message)
+	}), &failures
+}
+
+func TestConversation(t *testing.T) {
+	t.Run("should run the steps", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(io.EOF), time.Second).
+			Send(&errdetails.ErrorInfo{Reason: "a"}).
+			ExpectRecv(gomega.BeAssignableToTypeOf(&errdetails.ErrorInfo{})).
+			CloseSend().
+			ExpectStatus(gomega.WithTransform(func(st *status.Status) codes.Code { return st.Code() }, gomega.Equal(codes.OK)))
+		assert.Empty(t, *failures)
+	})
+
+	t.Run("should report the failed step with the transcript", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(io.EOF), time.Second).
+			Send(&errdetails.ErrorInfo{Reason: "a"}).
+			ExpectRecv(gomega.Equal("b")).
+			CloseSend()
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], "Conversation failed at step 2 (expect recv):")
+		assert.Contains(t, (*failures)[0], "Transcript:\n    1. sent {\"reason\":\"a\"}\n    2. received {\"reason\":\"a\"}")
+		assert.NotContains(t, (*failures)[0], "closed send")
+	})
+
+	t.Run("should fail to receive from an ended stream", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(status.Error(codes.Aborted, "aborted")), time.Second).
+			CloseSend().
+			ExpectRecv(gomega.BeNil())
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], `Expected to receive a message, but the stream ended with Aborted (10) "aborted"`)
+	})
+
+	t.Run("should fail to end a stream that receives a message", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(io.EOF), time.Second).
+			Send(&errdetails.ErrorInfo{Reason: "a"}).
+			ExpectStatus(gomega.BeNil())
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], `Expected the stream to end, but it received {"reason":"a"}`)
+	})
+
+	t.Run("should fail when the timeout elapses", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(io.EOF), time.Second).
+			Within(10 * time.Millisecond).
+			ExpectRecv(gomega.BeNil())
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], "receiving: timed out after the conversation ran for 10ms")
+		assert.Contains(t, (*failures)[0], "1. timed out")
+	})
+
+	t.Run("should cancel a CancelableStream when the timeout elapses", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		stream := newFakeBidiStream(status.Error(codes.Canceled, "canceled"))
+		canceled := make(chan struct{})
+		NewConversation(g, &CancelableStream{Stream: stream, Cancel: func() {
+			close(canceled)
+			close(stream.messages)
+		}}, 10*time.Millisecond).
+			ExpectRecv(gomega.BeNil()).
+			ExpectStatus(gomega.BeNil())
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], "receiving: timed out after the conversation ran for 10ms")
+		assert.NotContains(t, (*failures)[0], "ended with")
+		_, open := <-canceled
+		assert.False(t, open)
+	})
+
+	t.Run("should fail to send messages of other types", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeBidiStream(io.EOF), time.Second).
+			Send(&errdetails.RequestInfo{})
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], "cannot send a *errdetails.RequestInfo on a *matchersimpl.fakeBidiStream")
+	})
+
+	t.Run("should fail on values that are not bidirectional streams", func(t *testing.T) {
+		g, failures := newFailuresGomega()
+		NewConversation(g, newFakeStream(io.EOF), time.Second).
+			CloseSend()
+		require.Len(t, *failures, 1)
+		assert.Contains(t, (*failures)[0], errExpectedBidiStream.Error())
+	})
+}
@@ -0,0 +1,101 @@
+package matchersimpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// DetailFormat selects how the status details are rendered.
+type DetailFormat int
+
+const (
+	// DetailFormatJSON renders the details as single line protojson.
+	DetailFormatJSON DetailFormat = iota
+	// DetailFormatText renders the details as single line prototext.
+	DetailFormatText
+)
+
+// statusDetailsFormat is the DetailFormat used by the failure messages. See SetStatusDetailsFormat.
+var statusDetailsFormat = DetailFormatJSON
+
+// StatusDetailsFormat returns the DetailFormat used to render the status details on failure messages.
+func StatusDetailsFormat() DetailFormat {
+	return statusDetailsFormat
+}
+
+// SetStatusDetailsFormat changes the DetailFormat used to render the status details on failure messages, returning the
+// previous one.
+func SetStatusDetailsFormat(detailFormat DetailFormat) DetailFormat {
+	previous := statusDetailsFormat
+	statusDetailsFormat = detailFormat
+	return previous
+}
+
+// detailHexPreviewSize is the maximum number of bytes shown for the details whose types are unknown.
+const detailHexPreviewSize = 32
+
+// FormatStatusDetail renders a detail preceded by its type URL (eg:
+// `type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"}`).
+//
+// Details whose types are not linked into the binary cannot be decoded, so they are rendered with their raw type URL
+// and a hex preview of their bytes.
+func FormatStatusDetail(anyDetail *anypb.Any, detailFormat DetailFormat) string {
+	detail, err := anyDetail.UnmarshalNew()
+	if err != nil {
+		return fmt.Sprintf("%s: <unknown type> %s", anyDetail.GetTypeUrl(), hexPreview(anyDetail.GetValue()))
+	}
+	return anyDetail.GetTypeUrl() + ": " + FormatMessage(detail, detailFormat)
+}
+
+// FormatStatus renders the code name and number, message and details of st, one per line. Details are rendered with
+// FormatStatusDetail, using the StatusDetailsFormat:
+//
+//	{
+//	    Status: NotFound,
+//	    StatusCode: 5,
+//	    Message: book not found,
+//	    Details: [
+//	        type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"},
+//	    ],
+//	}
+func FormatStatus(st *status.Status) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	fmt.Fprintf(&sb, "%sStatus: %s,\n", format.Indent, st.Code())
+	fmt.Fprintf(&sb, "%sStatusCode: %d,\n", format.Indent, st.Code())
+	fmt.Fprintf(&sb, "%sMessage: %s,\n", format.Indent, st.Message())
+	details := st.Proto().GetDetails()
+	if len(details) == 0 {
+		fmt.Fprintf(&sb, "%sDetails: [],\n", format.Indent)
+	} else {
+		fmt.Fprintf(&sb, "%sDetails: [\n", format.Indent)
+		for _, anyDetail := range details {
+			fmt.Fprintf(&sb, "%s%s%s,\n", format.Indent, format.Indent, FormatStatusDetail(anyDetail, StatusDetailsFormat()))
+		}
+		fmt.Fprintf(&sb, "%s],\n", format.Indent)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// FormatMessage renders m in a single line, as protojson or as prototext between braces.
+func FormatMessage(m proto.Message, detailFormat DetailFormat) string {
+	if detailFormat == DetailFormatText && m != nil && m.ProtoReflect().IsValid() {
+		return "{" + prototext.MarshalOptions{}.Format(m) + "}"
+	}
+	return formatMessage(m)
+}
+
+func hexPreview(value []byte) string {
+	if len(value) <= detailHexPreviewSize {
+		return fmt.Sprintf("%s (%d bytes)", hex.EncodeToString(value), len(value))
+	}
+	return fmt.Sprintf("%s... (%d bytes)", hex.EncodeToString(value[:detailHexPreviewSize]), len(value))
+}
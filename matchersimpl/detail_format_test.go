@@ -0,0 +1,68 @@
+package matchersimpl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestFormatStatusDetail(t *testing.T) {
+	errorInfo, err := anypb.New(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})
+	require.NoError(t, err)
+
+	t.Run("should render as JSON", func(t *testing.T) {
+		assert.Equal(t, `type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"}`, FormatStatusDetail(errorInfo, DetailFormatJSON))
+	})
+
+	t.Run("should render as text", func(t *testing.T) {
+		assert.Regexp(t, `^type.googleapis.com/google.rpc.ErrorInfo: \{reason:\s*"BOOK_NOT_FOUND"\}$`, FormatStatusDetail(errorInfo, DetailFormatText))
+	})
+
+	t.Run("should render unknown types with a hex preview", func(t *testing.T) {
+		unknown := &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Detail", Value: []byte{0x0a, 0x02, 0x68, 0x69}}
+		assert.Equal(t, "type.googleapis.com/unknown.Detail: <unknown type> 0a026869 (4 bytes)", FormatStatusDetail(unknown, DetailFormatJSON))
+	})
+
+	t.Run("should truncate the hex preview", func(t *testing.T) {
+		unknown := &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Detail", Value: bytes.Repeat([]byte{0xff}, 40)}
+		gotMessage := FormatStatusDetail(unknown, DetailFormatJSON)
+		assert.Contains(t, gotMessage, "<unknown type> "+string(bytes.Repeat([]byte("ff"), detailHexPreviewSize))+"... (40 bytes)")
+	})
+}
+
+func TestFormatStatus(t *testing.T) {
+	t.Run("should render the code, message and details", func(t *testing.T) {
+		st, err := status.New(codes.NotFound, "book not found").WithDetails(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})
+		require.NoError(t, err)
+		assert.Equal(t, `{
+    Status: NotFound,
+    StatusCode: 5,
+    Message: book not found,
+    Details: [
+        type.googleapis.com/google.rpc.ErrorInfo: {"reason":"BOOK_NOT_FOUND"},
+    ],
+}`, FormatStatus(st))
+	})
+
+	t.Run("should render a status without details", func(t *testing.T) {
+		assert.Contains(t, FormatStatus(status.New(codes.Internal, "internal")), "Details: [],")
+	})
+}
+
+func TestFormatMessage(t *testing.T) {
+	errorInfo := &errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"}
+
+	t.Run("should render as JSON", func(t *testing.T) {
+		assert.Equal(t, `{"reason":"BOOK_NOT_FOUND"}`, FormatMessage(errorInfo, DetailFormatJSON))
+	})
+
+	t.Run("should render as text", func(t *testing.T) {
+		assert.Regexp(t, `^\{reason:\s*"BOOK_NOT_FOUND"\}$`, FormatMessage(errorInfo, DetailFormatText))
+	})
+}
@@ -0,0 +1,63 @@
+package matchersimpl
+
+import (
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// DetailProp is a property of an item of type V found in the status details (eg: the subject of an
+// errdetails.QuotaFailure_Violation) and the matcher applied to it. A nil Matcher matches any value.
+type DetailProp[V any] struct {
+	Name    string
+	PropMap func(item V) interface{}
+	Matcher types.GomegaMatcher
+}
+
+// matchAnyItem checks if any of the items has all its properties matched.
+func matchAnyItem[V any](items []V, props []DetailProp[V]) (bool, error) {
+	for _, item := range items {
+		matched, err := matchItem(item, props)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchItem[V any](item V, props []DetailProp[V]) (bool, error) {
+	for _, prop := range props {
+		if prop.Matcher == nil {
+			continue
+		}
+		matched, err := prop.Matcher.Match(prop.PropMap(item))
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// describeDetailProps renders the properties and their matchers, one per line, to be used on failure messages.
+func describeDetailProps[V any](props []DetailProp[V]) string {
+	lines := make([]string, 0, len(props))
+	for _, prop := range props {
+		description := "<any>"
+		if prop.Matcher != nil {
+			description = strings.TrimSpace(format.Object(prop.Matcher, 1))
+		}
+		lines = append(lines, format.Indent+prop.Name+": "+description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// violationFailureMessage builds the failure message of the violation matchers, rendering the detail as JSON instead
+// of the generated Go struct.
+func violationFailureMessage[V any](detail proto.Message, message string, props []DetailProp[V]) string {
+	return format.Message(formatMessage(detail), message) + "\n" + describeDetailProps(props)
+}
@@ -3,7 +3,6 @@ package matchersimpl
 import (
 	"github.com/onsi/gomega/format"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type GRPCErrorCodeMatcher struct {
@@ -17,16 +16,12 @@ func MatchGRPCStatusCode(code codes.Code) *GRPCErrorCodeMatcher {
 	}
 }
 
-// Match checks if the given actual is an error and a status.Status. If so, it tries to match the actual status code
+// Match checks if the given actual can be converted to a status.Status (see statusFromActual). If so, it tries to match the actual status code
 // with the expected one.
 func (matcher *GRPCErrorCodeMatcher) Match(actual interface{}) (success bool, err error) {
-	actualErr, ok := actual.(error)
-	if !ok {
-		return false, errExpectedError
-	}
-	st, ok := status.FromError(actualErr)
-	if !ok {
-		return false, errStatusErrExpected
+	st, err := statusFromActual(actual)
+	if err != nil {
+		return false, err
 	}
 
 	return st.Code() == matcher.expectedCode, nil
@@ -35,29 +30,21 @@ func (matcher *GRPCErrorCodeMatcher) Match(actual interface{}) (success bool, er
 // FailureMessage returns the error messages when this matcher does not receive an error, neither a status.Status or
 // the status code does not match.
 func (matcher *GRPCErrorCodeMatcher) FailureMessage(actual interface{}) (message string) {
-	actualErr, ok := actual.(error)
-	if !ok {
-		return format.Message(actual, "is not an error")
-	}
-	st, ok := status.FromError(actualErr)
-	if !ok {
-		return format.Message(actual, "is not a grpc error")
+	st, err := statusFromActual(actual)
+	if err != nil {
+		return actualShapeMessage(actual, err)
 	}
 
-	return format.Message(st.Code(), "to match", matcher.expectedCode)
+	return format.Message(FormatStatus(st), "to match the code", formatCode(matcher.expectedCode))
 }
 
 // NegatedFailureMessage returns the error messages when this matcher does not receive an error, neither a status.Status
 // or the status code does not match negating the sentence.
 func (matcher *GRPCErrorCodeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	actualErr, ok := actual.(error)
-	if !ok {
-		return format.Message(actual, "is not an error")
-	}
-	st, ok := status.FromError(actualErr)
-	if !ok {
-		return format.Message(actual, "is not a grpc error")
+	st, err := statusFromActual(actual)
+	if err != nil {
+		return actualShapeMessage(actual, err)
 	}
 
-	return format.Message(actual, "not to match", st.Code())
+	return format.Message(FormatStatus(st), "not to match the code", formatCode(matcher.expectedCode))
 }
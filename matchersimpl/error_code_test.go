@@ -49,7 +49,7 @@ func TestGRPCErrorCodeMatcher_FailureMessage(t *testing.T) {
 	}{
 		{"should fail when no error given", false, codes.OK, "is not an error"},
 		{"should fail when no status given", errors.New("non status error"), codes.OK, "is not a grpc error"},
-		{"should not match", status.New(codes.InvalidArgument, "invalid argument").Err(), codes.NotFound, "to match"},
+		{"should not match", status.New(codes.InvalidArgument, "invalid argument").Err(), codes.NotFound, "to match the code\n    <string>: NotFound (5)"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,6 +60,14 @@ func TestGRPCErrorCodeMatcher_FailureMessage(t *testing.T) {
 			assert.Contains(t, gotMessage, tt.wantMessage)
 		})
 	}
+
+	t.Run("should render the status", func(t *testing.T) {
+		matcher := &GRPCErrorCodeMatcher{expectedCode: codes.NotFound}
+		gotMessage := matcher.FailureMessage(status.New(codes.InvalidArgument, "invalid argument").Err())
+		assert.Contains(t, gotMessage, "Status: InvalidArgument,")
+		assert.Contains(t, gotMessage, "Message: invalid argument,")
+		assert.NotContains(t, gotMessage, "sizeCache")
+	})
 }
 
 func TestGRPCErrorCodeMatcher_NegatedFailureMessage(t *testing.T) {
@@ -71,7 +79,7 @@ func TestGRPCErrorCodeMatcher_NegatedFailureMessage(t *testing.T) {
 	}{
 		{"should fail when no error given", false, codes.OK, "is not an error"},
 		{"should fail when no status given", errors.New("non status error"), codes.OK, "is not a grpc error"},
-		{"should not match", status.New(codes.InvalidArgument, "invalid argument").Err(), codes.NotFound, "not to match"},
+		{"should not match", status.New(codes.InvalidArgument, "invalid argument").Err(), codes.NotFound, "not to match the code\n    <string>: NotFound (5)"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
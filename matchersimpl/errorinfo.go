@@ -3,7 +3,6 @@ package matchersimpl
 import (
 	"errors"
 
-	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
@@ -42,7 +41,7 @@ func (m *GRPCErrorInfoMatcher) Match(st *status.Status) (bool, error) {
 func (m *GRPCErrorInfoMatcher) FailureMessage(st *status.Status) string {
 	errInfo, ok := findErrorInfo(st)
 	if !ok {
-		return format.Message(st, "does not have an *errdetails.ErrorInfo in the details")
+		return detailNotFoundMessage[*errdetails.ErrorInfo](st)
 	}
 	return m.errorInfoMatcher.FailureMessage(errInfo)
 }
@@ -50,7 +49,7 @@ func (m *GRPCErrorInfoMatcher) FailureMessage(st *status.Status) string {
 func (m *GRPCErrorInfoMatcher) NegatedFailureMessage(st *status.Status) string {
 	errInfo, ok := findErrorInfo(st)
 	if !ok {
-		return format.Message(st, "does not have an *errdetails.ErrorInfo in the details")
+		return detailNotFoundMessage[*errdetails.ErrorInfo](st)
 	}
 	return m.errorInfoMatcher.NegatedFailureMessage(errInfo)
 }
@@ -77,11 +76,5 @@ func (m *GRPCErrorInfoReasonMatcher) NegatedFailureMessage(errInfo *errdetails.E
 //
 // Otherwise, it returns false.
 func findErrorInfo(st *status.Status) (*errdetails.ErrorInfo, bool) {
-	for _, d := range st.Details() {
-		errInfo, ok := d.(*errdetails.ErrorInfo)
-		if ok {
-			return errInfo, true
-		}
-	}
-	return nil, false
+	return findStatusDetail[*errdetails.ErrorInfo](st)
 }
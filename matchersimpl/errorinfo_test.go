@@ -75,6 +75,7 @@ func TestGRPCErrorInfoMatcher_FailureMessage(t *testing.T) {
 		matcher := &GRPCErrorInfoMatcher{nil}
 		gotMessage := matcher.FailureMessage(status.New(codes.Internal, "random error"))
 		assert.Contains(t, gotMessage, "does not have an *errdetails.ErrorInfo in the details")
+		assert.Contains(t, gotMessage, "<no details>")
 	})
 
 	t.Run("should fail finding ErrorInfo", func(t *testing.T) {
@@ -100,6 +101,7 @@ func TestGRPCErrorInfoMatcher_NegatedFailureMessage(t *testing.T) {
 		matcher := &GRPCErrorInfoMatcher{nil}
 		gotMessage := matcher.NegatedFailureMessage(status.New(codes.Internal, "random error"))
 		assert.Contains(t, gotMessage, "does not have an *errdetails.ErrorInfo in the details")
+		assert.Contains(t, gotMessage, "<no details>")
 	})
 
 	t.Run("should fail finding ErrorInfo", func(t *testing.T) {
@@ -0,0 +1,181 @@
+package matchersimpl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// requestFieldPath is a segment of a request field path, as reported by google.rpc.BadRequest field violations: a
+// field name followed by the indexes or map keys between brackets (eg: `items[0]`).
+type requestFieldPath struct {
+	name string
+	keys []string
+}
+
+// parseRequestFieldPath splits path into its segments. Dots between brackets (eg: map keys) do not split segments,
+// and segments made of digits only (eg: the `0` of `items.0.sku`) are taken as indexes of the previous segment.
+func parseRequestFieldPath(path string) []requestFieldPath {
+	var (
+		segments []requestFieldPath
+		current  requestFieldPath
+		sb       strings.Builder
+		depth    int
+	)
+	flush := func() {
+		if depth > 0 || sb.Len() == 0 {
+			return
+		}
+		if current.name == "" && len(current.keys) == 0 && isIndex(sb.String()) && len(segments) > 0 {
+			segments[len(segments)-1].keys = append(segments[len(segments)-1].keys, sb.String())
+			sb.Reset()
+			return
+		}
+		current.name = sb.String()
+		sb.Reset()
+	}
+	for _, r := range path {
+		switch {
+		case r == '[' && depth == 0:
+			flush()
+			depth++
+		case r == ']' && depth == 1:
+			current.keys = append(current.keys, sb.String())
+			sb.Reset()
+			depth--
+		case r == '.' && depth == 0:
+			flush()
+			if current.name != "" || len(current.keys) > 0 {
+				segments = append(segments, current)
+			}
+			current = requestFieldPath{}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	flush()
+	if current.name != "" || len(current.keys) > 0 {
+		segments = append(segments, current)
+	}
+	return segments
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// normalizeFieldPath rewrites a field path to the AIP-161 syntax used by google.rpc.BadRequest: snake_case field
+// names separated by dots, followed by their indexes or map keys between brackets. So, `items.0.sku`,
+// `Items[0].Sku` and `items[0].sku` are all normalized to `items[0].sku`.
+func normalizeFieldPath(path string) string {
+	var sb strings.Builder
+	for i, segment := range parseRequestFieldPath(path) {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(snakeCase(segment.name))
+		for _, key := range segment.keys {
+			sb.WriteString("[" + key + "]")
+		}
+	}
+	return sb.String()
+}
+
+// snakeCase converts Go (`OrderID`) and JSON (`orderId`) field names to their proto names (`order_id`).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLowerOrDigit := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLowerOrDigit || acronymEnd {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// validateRequestFieldPath checks if the given path, already normalized, points to an existing field of md. Indexes
+// are only accepted on repeated fields and keys on map fields.
+func validateRequestFieldPath(md protoreflect.MessageDescriptor, path string) error {
+	var previous protoreflect.FieldDescriptor
+	for _, segment := range parseRequestFieldPath(path) {
+		if md == nil {
+			return fmt.Errorf("%w %q: %s is not a message", errInvalidFieldPath, path, previous.Name())
+		}
+		fd := findField(md, segment.name)
+		if fd == nil {
+			return fmt.Errorf("%w %q: %s has no field %q%s", errInvalidFieldPath, path, md.FullName(), segment.name, fieldSuggestion(md, segment.name))
+		}
+		switch {
+		case len(segment.keys) > 1:
+			return fmt.Errorf("%w %q: %s has too many indexes", errInvalidFieldPath, path, fd.Name())
+		case len(segment.keys) == 1 && fd.IsList() && !isIndex(segment.keys[0]):
+			return fmt.Errorf("%w %q: %s is a repeated field, the index must be a number", errInvalidFieldPath, path, fd.Name())
+		case len(segment.keys) == 1 && !fd.IsList() && !fd.IsMap():
+			return fmt.Errorf("%w %q: %s is not a repeated or map field", errInvalidFieldPath, path, fd.Name())
+		}
+		md, previous = fieldMessage(fd), fd
+	}
+	return nil
+}
+
+// fieldSuggestion suggests the closest field name of md, if name looks mistyped.
+func fieldSuggestion(md protoreflect.MessageDescriptor, name string) string {
+	fields := md.Fields()
+	names := make([]string, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		names[i] = string(fields.Get(i).Name())
+	}
+	closest, ok := closestName(name, names)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", closest)
+}
+
+// GRPCFieldPathMatcher implements a gomega.Matcher that compares field paths after normalizing both to the AIP-161
+// syntax (see normalizeFieldPath).
+//
+// If ValidateRequest is set, the Expected path is validated against the descriptor of the Request before matching, so
+// assertions on fields that no longer exist fail with an error. A nil Request fails with an error too.
+type GRPCFieldPathMatcher struct {
+	Expected        string
+	Request         proto.Message
+	ValidateRequest bool
+}
+
+func (m *GRPCFieldPathMatcher) Match(actual interface{}) (bool, error) {
+	path, ok := actual.(string)
+	if !ok {
+		return false, fmt.Errorf("%w: expected a string, got %T", errInvalidFieldPath, actual)
+	}
+	expected := normalizeFieldPath(m.Expected)
+	if m.ValidateRequest {
+		if m.Request == nil {
+			return false, fmt.Errorf("%w %q: cannot validate it against a nil request", errInvalidFieldPath, m.Expected)
+		}
+		if err := validateRequestFieldPath(m.Request.ProtoReflect().Descriptor(), expected); err != nil {
+			return false, err
+		}
+	}
+	return normalizeFieldPath(path) == expected, nil
+}
+
+func (m *GRPCFieldPathMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(actual, "to be the field path", normalizeFieldPath(m.Expected))
+}
+
+func (m *GRPCFieldPathMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(actual, "not to be the field path", normalizeFieldPath(m.Expected))
+}
@@ -0,0 +1,106 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_normalizeFieldPath(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{"items[0].sku", "items[0].sku"},
+		{"items.0.sku", "items[0].sku"},
+		{"Items[0].Sku", "items[0].sku"},
+		{"emailAddresses[1].email", "email_addresses[1].email"},
+		{"OrderID", "order_id"},
+		{"HTTPHeaders.0", "http_headers[0]"},
+		{`labels["app.kubernetes.io/name"]`, `labels["app.kubernetes.io/name"]`},
+		{"matrix.0.1", "matrix[0][1]"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeFieldPath(tt.given))
+		})
+	}
+}
+
+func Test_validateRequestFieldPath(t *testing.T) {
+	md := (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantErr     bool
+		wantMessage string
+	}{
+		{"should accept a field", "name", false, ""},
+		{"should accept a nested field", "options.java_package", false, ""},
+		{"should accept an indexed field", "message_type[0].field[1].name", false, ""},
+		{"should accept a repeated field without index", "message_type.name", false, ""},
+		{"should reject an unknown field", "message_type[0].fields[1].name", true, `google.protobuf.DescriptorProto has no field "fields", did you mean "field"?`},
+		{"should reject an index on a singular field", "options[0]", true, "options is not a repeated or map field"},
+		{"should reject a non numeric index", `message_type["a"]`, true, "the index must be a number"},
+		{"should reject fields of scalars", "name.value", true, "name is not a message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRequestFieldPath(md, tt.path)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errInvalidFieldPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMessage)
+		})
+	}
+
+	t.Run("should accept map keys", func(t *testing.T) {
+		assert.NoError(t, validateRequestFieldPath((&structpb.Struct{}).ProtoReflect().Descriptor(), `fields["name"].string_value`))
+	})
+}
+
+func TestGRPCFieldPathMatcher_Match(t *testing.T) {
+	request := &descriptorpb.FileDescriptorProto{}
+
+	tests := []struct {
+		name        string
+		matcher     *GRPCFieldPathMatcher
+		actual      interface{}
+		wantSuccess bool
+		wantErr     error
+	}{
+		{"should match the same path", &GRPCFieldPathMatcher{Expected: "items[0].sku"}, "items[0].sku", true, nil},
+		{"should match differently spelled paths", &GRPCFieldPathMatcher{Expected: "items[0].sku"}, "Items.0.Sku", true, nil},
+		{"should not match a different path", &GRPCFieldPathMatcher{Expected: "items[0].sku"}, "items[1].sku", false, nil},
+		{"should match a path of the request", &GRPCFieldPathMatcher{Expected: "messageType.0.name", Request: request, ValidateRequest: true}, "message_type[0].name", true, nil},
+		{"should fail with a path not in the request", &GRPCFieldPathMatcher{Expected: "message_types[0].name", Request: request, ValidateRequest: true}, "message_types[0].name", false, errInvalidFieldPath},
+		{"should fail with a nil request", &GRPCFieldPathMatcher{Expected: "name", ValidateRequest: true}, "name", false, errInvalidFieldPath},
+		{"should fail with a non string", &GRPCFieldPathMatcher{Expected: "name"}, 42, false, errInvalidFieldPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(tt.actual)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+}
+
+func TestGRPCFieldPathMatcher_FailureMessage(t *testing.T) {
+	gotMessage := (&GRPCFieldPathMatcher{Expected: "Items.0.Sku"}).FailureMessage("items[1].sku")
+	assert.Contains(t, gotMessage, "to be the field path")
+	assert.Contains(t, gotMessage, "items[0].sku")
+}
+
+func TestGRPCFieldPathMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCFieldPathMatcher{Expected: "Items.0.Sku"}).NegatedFailureMessage("items[0].sku")
+	assert.Contains(t, gotMessage, "not to be the field path")
+}
@@ -0,0 +1,75 @@
+package matchersimpl
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatusCodes maps the status codes to their HTTP equivalents, as done by grpc-gateway
+// (runtime.HTTPStatusFromCode).
+var httpStatusCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+// HTTPStatusFromCode returns the HTTP status equivalent to the given code, using the grpc-gateway mapping. Unknown
+// codes are mapped to 500.
+func HTTPStatusFromCode(code codes.Code) int {
+	if httpStatus, ok := httpStatusCodes[code]; ok {
+		return httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// CodesByHTTPStatus returns the codes, in ascending order, whose HTTP equivalents satisfy the given predicate (eg: the
+// 4xx codes).
+func CodesByHTTPStatus(predicate func(httpStatus int) bool) []codes.Code {
+	var result []codes.Code
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if predicate(HTTPStatusFromCode(code)) {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// GRPCHTTPStatusMatcher implements a StatusMatcher that succeeds if the HTTP equivalent of the status code is
+// Expected.
+type GRPCHTTPStatusMatcher struct {
+	Expected int
+}
+
+func (m *GRPCHTTPStatusMatcher) Match(st *status.Status) (bool, error) {
+	return HTTPStatusFromCode(st.Code()) == m.Expected, nil
+}
+
+func (m *GRPCHTTPStatusMatcher) FailureMessage(st *status.Status) string {
+	return format.Message(formatHTTPCode(st.Code()), "to have the HTTP equivalent", m.Expected) + "\n" + formatStatus(st)
+}
+
+func (m *GRPCHTTPStatusMatcher) NegatedFailureMessage(st *status.Status) string {
+	return format.Message(formatHTTPCode(st.Code()), "not to have the HTTP equivalent", m.Expected) + "\n" + formatStatus(st)
+}
+
+func formatHTTPCode(code codes.Code) string {
+	return fmt.Sprintf("%s -> HTTP %d", formatCode(code), HTTPStatusFromCode(code))
+}
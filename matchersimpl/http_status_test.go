@@ -0,0 +1,49 @@
+package matchersimpl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	assert.Equal(t, http.StatusOK, HTTPStatusFromCode(codes.OK))
+	assert.Equal(t, http.StatusNotFound, HTTPStatusFromCode(codes.NotFound))
+	assert.Equal(t, 499, HTTPStatusFromCode(codes.Canceled))
+	assert.Equal(t, http.StatusServiceUnavailable, HTTPStatusFromCode(codes.Unavailable))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatusFromCode(codes.Code(42)))
+}
+
+func TestCodesByHTTPStatus(t *testing.T) {
+	gotCodes := CodesByHTTPStatus(func(httpStatus int) bool {
+		return httpStatus == http.StatusConflict
+	})
+	assert.Equal(t, []codes.Code{codes.AlreadyExists, codes.Aborted}, gotCodes)
+}
+
+func TestGRPCHTTPStatusMatcher_Match(t *testing.T) {
+	st := status.New(codes.NotFound, "not found")
+
+	gotSuccess, err := (&GRPCHTTPStatusMatcher{Expected: http.StatusNotFound}).Match(st)
+	assert.NoError(t, err)
+	assert.True(t, gotSuccess)
+
+	gotSuccess, err = (&GRPCHTTPStatusMatcher{Expected: http.StatusBadRequest}).Match(st)
+	assert.NoError(t, err)
+	assert.False(t, gotSuccess)
+}
+
+func TestGRPCHTTPStatusMatcher_FailureMessage(t *testing.T) {
+	gotMessage := (&GRPCHTTPStatusMatcher{Expected: http.StatusBadRequest}).FailureMessage(status.New(codes.NotFound, "not found"))
+	assert.Contains(t, gotMessage, "NotFound (5) -> HTTP 404")
+	assert.Contains(t, gotMessage, "to have the HTTP equivalent")
+	assert.Contains(t, gotMessage, "400")
+}
+
+func TestGRPCHTTPStatusMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCHTTPStatusMatcher{Expected: http.StatusNotFound}).NegatedFailureMessage(status.New(codes.NotFound, "not found"))
+	assert.Contains(t, gotMessage, "not to have the HTTP equivalent")
+}
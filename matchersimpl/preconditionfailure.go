@@ -0,0 +1,46 @@
+package matchersimpl
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func NewGRPCMatchPreconditionFailure(matcher types.GomegaMatcher) *GRPCStatusMatcher {
+	return NewGRPCMatchStatusDetail[*errdetails.PreconditionFailure](matcher)
+}
+
+// GRPCPreconditionViolationMatcher matches an errdetails.PreconditionFailure that has at least one violation whose
+// Type, Subject and Description match the given matchers. A nil matcher matches any value.
+type GRPCPreconditionViolationMatcher struct {
+	Type        types.GomegaMatcher
+	Subject     types.GomegaMatcher
+	Description types.GomegaMatcher
+}
+
+func (m *GRPCPreconditionViolationMatcher) props() []DetailProp[*errdetails.PreconditionFailure_Violation] {
+	return []DetailProp[*errdetails.PreconditionFailure_Violation]{
+		{Name: "type", PropMap: func(v *errdetails.PreconditionFailure_Violation) interface{} { return v.GetType() }, Matcher: m.Type},
+		{Name: "subject", PropMap: func(v *errdetails.PreconditionFailure_Violation) interface{} { return v.GetSubject() }, Matcher: m.Subject},
+		{Name: "description", PropMap: func(v *errdetails.PreconditionFailure_Violation) interface{} { return v.GetDescription() }, Matcher: m.Description},
+	}
+}
+
+func (m *GRPCPreconditionViolationMatcher) Match(actual interface{}) (bool, error) {
+	preconditionFailure, ok := actual.(*errdetails.PreconditionFailure)
+	if !ok {
+		return false, fmt.Errorf("%w: expected an *errdetails.PreconditionFailure, got %T", errUnexpectedDetailType, actual)
+	}
+	return matchAnyItem(preconditionFailure.GetViolations(), m.props())
+}
+
+func (m *GRPCPreconditionViolationMatcher) FailureMessage(actual interface{}) string {
+	preconditionFailure, _ := actual.(*errdetails.PreconditionFailure)
+	return violationFailureMessage(preconditionFailure, "to have a precondition violation with", m.props())
+}
+
+func (m *GRPCPreconditionViolationMatcher) NegatedFailureMessage(actual interface{}) string {
+	preconditionFailure, _ := actual.(*errdetails.PreconditionFailure)
+	return violationFailureMessage(preconditionFailure, "not to have a precondition violation with", m.props())
+}
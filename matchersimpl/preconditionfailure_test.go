@@ -0,0 +1,57 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestGRPCPreconditionViolationMatcher_Match(t *testing.T) {
+	preconditionFailure := &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "type1", Subject: "subject1", Description: "description1"},
+			{Type: "type2", Subject: "subject2", Description: "description2"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		matcher     *GRPCPreconditionViolationMatcher
+		wantSuccess bool
+	}{
+		{"should match by type and subject", &GRPCPreconditionViolationMatcher{Type: gomega.Equal("type2"), Subject: gomega.Equal("subject2")}, true},
+		{"should match all properties", &GRPCPreconditionViolationMatcher{Type: gomega.Equal("type1"), Subject: gomega.Equal("subject1"), Description: gomega.Equal("description1")}, true},
+		{"should not match properties of different violations", &GRPCPreconditionViolationMatcher{Type: gomega.Equal("type1"), Subject: gomega.Equal("subject2")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(preconditionFailure)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+
+	t.Run("should fail when actual is not a PreconditionFailure", func(t *testing.T) {
+		gotSuccess, err := (&GRPCPreconditionViolationMatcher{}).Match(&errdetails.QuotaFailure{})
+		assert.ErrorIs(t, err, errUnexpectedDetailType)
+		assert.False(t, gotSuccess)
+	})
+}
+
+func TestGRPCPreconditionViolationMatcher_FailureMessage(t *testing.T) {
+	gotMessage := (&GRPCPreconditionViolationMatcher{Type: gomega.Equal("type3")}).FailureMessage(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "type1", Subject: "subject1"},
+		},
+	})
+	assert.Contains(t, gotMessage, `{"violations":[{"type":"type1","subject":"subject1"}]}`)
+	assert.Contains(t, gotMessage, "to have a precondition violation with")
+	assert.Contains(t, gotMessage, "type3")
+}
+
+func TestGRPCPreconditionViolationMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCPreconditionViolationMatcher{}).NegatedFailureMessage(&errdetails.PreconditionFailure{})
+	assert.Contains(t, gotMessage, "not to have a precondition violation with")
+}
@@ -0,0 +1,465 @@
+package matchersimpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/matchers/support/goraph/bipartitegraph"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoComparer compares messages with the same semantics of proto.Equal, extended by the ProtoEqualMatcher options.
+//
+// When reporting is set, the comparison does not stop at the first difference, it goes through the whole message
+// collecting all the differences found into diffs.
+type protoComparer struct {
+	// ignoreRepeatedOrder compares all repeated fields as multisets.
+	ignoreRepeatedOrder bool
+	// unorderedFields is the set of field keys (see fieldPath.key) of the repeated fields compared as multisets.
+	unorderedFields map[string]bool
+	// floatTolerance is applied to all float and double fields that do not have a tolerance in fieldFloatTolerances.
+	floatTolerance *FloatTolerance
+	// fieldFloatTolerances maps field keys to the tolerance applied to the float and double fields at, or under, them.
+	fieldFloatTolerances map[string]FloatTolerance
+	// equateNaNs makes NaN equal to NaN when comparing values with a tolerance.
+	equateNaNs bool
+
+	reporting bool
+	diffs     []string
+}
+
+// silent returns a copy of the comparer that does not report differences.
+func (c *protoComparer) silent() *protoComparer {
+	if !c.reporting {
+		return c
+	}
+	cp := *c
+	cp.reporting = false
+	cp.diffs = nil
+	return &cp
+}
+
+func (c *protoComparer) report(path string, format string, args ...interface{}) {
+	if !c.reporting {
+		return
+	}
+	if path == "" {
+		path = "<root>"
+	}
+	c.diffs = append(c.diffs, path+": "+fmt.Sprintf(format, args...))
+}
+
+// equal compares the expected x with the actual y.
+func (c *protoComparer) equal(x, y proto.Message) bool {
+	if x == nil || y == nil {
+		if x != nil || y != nil {
+			c.report("", "%s -> %s", formatMessage(x), formatMessage(y))
+		}
+		return x == nil && y == nil
+	}
+	mx, my := x.ProtoReflect(), y.ProtoReflect()
+	if mx.IsValid() != my.IsValid() {
+		c.report("", "%s -> %s", formatMessage(x), formatMessage(y))
+		return false
+	}
+	return c.equalMessage("", "", mx, my)
+}
+
+func (c *protoComparer) equalMessage(path, key string, x, y protoreflect.Message) bool {
+	if x.Descriptor().FullName() != y.Descriptor().FullName() {
+		c.report(path, "type %s -> %s", x.Descriptor().FullName(), y.Descriptor().FullName())
+		return false
+	}
+	equal := true
+	switched := c.equalOneofs(path, x, y)
+	if len(switched) > 0 {
+		if !c.reporting {
+			return false
+		}
+		equal = false
+	}
+	fields := x.Descriptor().Fields()
+	for i := 0; i < fields.Len() && (equal || c.reporting); i++ {
+		if fd := fields.Get(i); !switched[fd.Number()] {
+			equal = c.equalMessageField(path, key, fd, x, y) && equal
+		}
+	}
+	for _, fd := range populatedExtensions(x, y) {
+		if !equal && !c.reporting {
+			break
+		}
+		equal = c.equalMessageField(path, key, fd, x, y) && equal
+	}
+	if !equalUnknown(x.GetUnknown(), y.GetUnknown()) {
+		c.report(path, "unknown fields differ")
+		equal = false
+	}
+	return equal
+}
+
+// equalOneofs reports the oneofs that have different fields populated on x and y. It returns the numbers of the
+// fields of these oneofs, so they are not compared again.
+func (c *protoComparer) equalOneofs(path string, x, y protoreflect.Message) map[protoreflect.FieldNumber]bool {
+	var switched map[protoreflect.FieldNumber]bool
+	oneofs := x.Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		fx, fy := x.WhichOneof(od), y.WhichOneof(od)
+		if fx == nil || fy == nil || fx.Number() == fy.Number() {
+			continue
+		}
+		if switched == nil {
+			switched = make(map[protoreflect.FieldNumber]bool)
+		}
+		switched[fx.Number()], switched[fy.Number()] = true, true
+		name := string(od.Name())
+		if path != "" {
+			name = path + "." + name
+		}
+		c.report("oneof "+name, "switched from %s = %s to %s = %s",
+			fx.Name(), formatValue(fx, x.Get(fx)), fy.Name(), formatValue(fy, y.Get(fy)))
+		if !c.reporting {
+			break
+		}
+	}
+	return switched
+}
+
+// populatedExtensions returns the extension fields populated in x or y, sorted by their full names.
+func populatedExtensions(x, y protoreflect.Message) []protoreflect.FieldDescriptor {
+	extensions := make(map[protoreflect.FullName]protoreflect.FieldDescriptor)
+	rangeExtensions := func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.IsExtension() {
+			extensions[fd.FullName()] = fd
+		}
+		return true
+	}
+	x.Range(rangeExtensions)
+	y.Range(rangeExtensions)
+	result := make([]protoreflect.FieldDescriptor, 0, len(extensions))
+	for _, fd := range extensions {
+		result = append(result, fd)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FullName() < result[j].FullName()
+	})
+	return result
+}
+
+func (c *protoComparer) equalMessageField(path, key string, fd protoreflect.FieldDescriptor, x, y protoreflect.Message) bool {
+	hx, hy := x.Has(fd), y.Has(fd)
+	if !hx && !hy {
+		return true
+	}
+	path, key = joinFieldPath(path, fd), joinFieldPath(key, fd)
+	vx, vy := x.Get(fd), y.Get(fd)
+	switch {
+	case fd.IsList():
+		if c.ignoreRepeatedOrder || c.unorderedFields[key] {
+			return c.equalUnorderedList(path, key, fd, vx.List(), vy.List())
+		}
+		return c.equalList(path, key, fd, vx.List(), vy.List())
+	case fd.IsMap():
+		return c.equalMap(path, key, fd, vx.Map(), vy.Map())
+	case hx != hy:
+		c.report(path, "%s -> %s", formatFieldValue(fd, x, hx), formatFieldValue(fd, y, hy))
+		return false
+	default:
+		return c.equalValue(path, key, fd, vx, vy)
+	}
+}
+
+func (c *protoComparer) equalList(path, key string, fd protoreflect.FieldDescriptor, x, y protoreflect.List) bool {
+	if x.Len() != y.Len() && !c.reporting {
+		return false
+	}
+	equal := x.Len() == y.Len()
+	for i := 0; i < x.Len() && i < y.Len() && (equal || c.reporting); i++ {
+		equal = c.equalValue(fmt.Sprintf("%s[%d]", path, i), key, fd, x.Get(i), y.Get(i)) && equal
+	}
+	for i := y.Len(); i < x.Len(); i++ {
+		c.report(fmt.Sprintf("%s[%d]", path, i), "removed %s", formatValue(fd, x.Get(i)))
+	}
+	for i := x.Len(); i < y.Len(); i++ {
+		c.report(fmt.Sprintf("%s[%d]", path, i), "added %s", formatValue(fd, y.Get(i)))
+	}
+	return equal
+}
+
+// equalUnorderedList compares the lists as multisets: each element of x must be matched by a distinct equal element
+// of y, regardless of their positions. With tolerances, an element can be equal to more than one element of the other
+// list, so the elements are paired by the largest matching of a bipartite graph instead of the first equal element.
+func (c *protoComparer) equalUnorderedList(path, key string, fd protoreflect.FieldDescriptor, x, y protoreflect.List) bool {
+	if x.Len() != y.Len() && !c.reporting {
+		return false
+	}
+	quiet := c.silent()
+	xValues, yValues := listValues(x), listValues(y)
+	graph, err := bipartitegraph.NewBipartiteGraph(xValues, yValues, func(vx, vy interface{}) (bool, error) {
+		return quiet.equalValue(path, key, fd, vx.(protoreflect.Value), vy.(protoreflect.Value)), nil
+	})
+	if err != nil {
+		c.report(path, "%s", err)
+		return false
+	}
+	edges := graph.LargestMatching()
+	if len(edges) == len(xValues) && len(edges) == len(yValues) {
+		return true
+	}
+	if !c.reporting {
+		return false
+	}
+	missing, unexpected := graph.FreeLeftRight(edges)
+	var sb strings.Builder
+	sb.WriteString("elements do not match regardless of the order")
+	writeListValues(&sb, "missing from actual", fd, toProtoValues(missing))
+	writeListValues(&sb, "unexpected in actual", fd, toProtoValues(unexpected))
+	c.report(path, "%s", sb.String())
+	return false
+}
+
+// listValues returns the elements of the list as the values of the nodes of a bipartite graph.
+func listValues(list protoreflect.List) []interface{} {
+	values := make([]interface{}, list.Len())
+	for i := range values {
+		values[i] = list.Get(i)
+	}
+	return values
+}
+
+// toProtoValues converts the values of the nodes of a bipartite graph back to protoreflect.Value.
+func toProtoValues(values []interface{}) []protoreflect.Value {
+	result := make([]protoreflect.Value, len(values))
+	for i, v := range values {
+		result[i] = v.(protoreflect.Value)
+	}
+	return result
+}
+
+func (c *protoComparer) equalMap(path, key string, fd protoreflect.FieldDescriptor, x, y protoreflect.Map) bool {
+	if x.Len() != y.Len() && !c.reporting {
+		return false
+	}
+	equal := true
+	for _, k := range sortedMapKeys(x, y) {
+		elemPath := fmt.Sprintf("%s[%s]", path, formatMapKey(k))
+		switch {
+		case !y.Has(k):
+			c.report(elemPath, "removed %s", formatValue(fd.MapValue(), x.Get(k)))
+			equal = false
+		case !x.Has(k):
+			c.report(elemPath, "added %s", formatValue(fd.MapValue(), y.Get(k)))
+			equal = false
+		default:
+			equal = c.equalValue(elemPath, key, fd.MapValue(), x.Get(k), y.Get(k)) && equal
+		}
+		if !equal && !c.reporting {
+			return false
+		}
+	}
+	return equal
+}
+
+// sortedMapKeys returns the keys of both maps, without duplicates, sorted by their values.
+func sortedMapKeys(x, y protoreflect.Map) []protoreflect.MapKey {
+	seen := make(map[interface{}]bool, x.Len())
+	keys := make([]protoreflect.MapKey, 0, x.Len())
+	collect := func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !seen[k.Interface()] {
+			seen[k.Interface()] = true
+			keys = append(keys, k)
+		}
+		return true
+	}
+	x.Range(collect)
+	y.Range(collect)
+	sort.Slice(keys, func(i, j int) bool {
+		switch ki := keys[i].Interface().(type) {
+		case bool:
+			return !ki && keys[j].Bool()
+		case string:
+			return ki < keys[j].String()
+		case int32, int64:
+			return keys[i].Int() < keys[j].Int()
+		default:
+			return keys[i].Uint() < keys[j].Uint()
+		}
+	})
+	return keys
+}
+
+func (c *protoComparer) equalValue(path, key string, fd protoreflect.FieldDescriptor, x, y protoreflect.Value) bool {
+	var equal bool
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return c.equalMessage(path, key, x.Message(), y.Message())
+	case protoreflect.BoolKind:
+		equal = x.Bool() == y.Bool()
+	case protoreflect.EnumKind:
+		equal = x.Enum() == y.Enum()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		equal = x.Int() == y.Int()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		equal = x.Uint() == y.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		equal = c.equalFloat(key, x.Float(), y.Float())
+	case protoreflect.StringKind:
+		equal = x.String() == y.String()
+	case protoreflect.BytesKind:
+		equal = bytes.Equal(x.Bytes(), y.Bytes())
+	default:
+		equal = x.Interface() == y.Interface()
+	}
+	if !equal {
+		c.report(path, "%s -> %s", formatValue(fd, x), formatValue(fd, y))
+	}
+	return equal
+}
+
+// equalFloat compares float values using the tolerance configured for the key. If there is no tolerance, values are
+// compared as proto.Equal does, with NaNs being equal.
+func (c *protoComparer) equalFloat(key string, x, y float64) bool {
+	tolerance, ok := c.floatToleranceFor(key)
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return (!ok || c.equateNaNs) && math.IsNaN(x) && math.IsNaN(y)
+	}
+	if !ok {
+		return x == y
+	}
+	return tolerance.equal(x, y)
+}
+
+// floatToleranceFor finds the tolerance of the closest field to key that has one, falling back to floatTolerance.
+func (c *protoComparer) floatToleranceFor(key string) (FloatTolerance, bool) {
+	for k := key; len(c.fieldFloatTolerances) > 0; {
+		if tolerance, ok := c.fieldFloatTolerances[k]; ok {
+			return tolerance, true
+		}
+		i := strings.LastIndexByte(k, '.')
+		if i < 0 {
+			break
+		}
+		k = k[:i]
+	}
+	if c.floatTolerance != nil {
+		return *c.floatTolerance, true
+	}
+	return FloatTolerance{}, false
+}
+
+// equalUnknown compares unknown fields by their raw bytes, grouped by field number. This is the same comparison made
+// by proto.Equal.
+func equalUnknown(x, y protoreflect.RawFields) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	if bytes.Equal(x, y) {
+		return true
+	}
+	mx := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	my := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	for len(x) > 0 {
+		fnum, _, n := protowire.ConsumeField(x)
+		mx[fnum] = append(mx[fnum], x[:n]...)
+		x = x[n:]
+	}
+	for len(y) > 0 {
+		fnum, _, n := protowire.ConsumeField(y)
+		my[fnum] = append(my[fnum], y[:n]...)
+		y = y[n:]
+	}
+	return reflect.DeepEqual(mx, my)
+}
+
+func joinFieldPath(path string, fd protoreflect.FieldDescriptor) string {
+	name := string(fd.Name())
+	if fd.IsExtension() {
+		name = "(" + string(fd.FullName()) + ")"
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func writeListValues(sb *strings.Builder, title string, fd protoreflect.FieldDescriptor, values []protoreflect.Value) {
+	if len(values) == 0 {
+		return
+	}
+	sb.WriteString("\n    ")
+	sb.WriteString(title)
+	sb.WriteString(":")
+	for _, v := range values {
+		sb.WriteString("\n      - ")
+		sb.WriteString(formatValue(fd, v))
+	}
+}
+
+func formatMessage(m proto.Message) string {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return "<nil>"
+	}
+	return formatValue(nil, protoreflect.ValueOfMessage(m.ProtoReflect()))
+}
+
+// formatFieldValue formats a singular field of m. Unpopulated fields that track presence are rendered as unset, so
+// they can be told apart from the zero values.
+func formatFieldValue(fd protoreflect.FieldDescriptor, m protoreflect.Message, has bool) string {
+	if !has && fd.HasPresence() {
+		return "unset"
+	}
+	return formatValue(fd, m.Get(fd))
+}
+
+// formatValue formats a singular value in a single line. Messages are rendered as compact JSON.
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd == nil {
+		return formatMessageValue(v.Message())
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return formatMessageValue(v.Message())
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%q", v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprintf("%d", v.Enum())
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// formatMessageValue renders the message as compact JSON.
+func formatMessageValue(m protoreflect.Message) string {
+	formatted := protojson.Format(m.Interface())
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(formatted)); err != nil {
+		return formatted
+	}
+	return buf.String()
+}
+
+func formatMapKey(k protoreflect.MapKey) string {
+	if s, ok := k.Interface().(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return k.String()
+}
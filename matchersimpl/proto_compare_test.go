@@ -0,0 +1,205 @@
+package matchersimpl
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoComparer_equal(t *testing.T) {
+	badRequest := func(fields ...string) *errdetails.BadRequest {
+		br := &errdetails.BadRequest{}
+		for _, f := range fields {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: f})
+		}
+		return br
+	}
+
+	tests := []struct {
+		name      string
+		comparer  *protoComparer
+		x, y      proto.Message
+		wantEqual bool
+	}{
+		{"should match nil messages", &protoComparer{}, nil, nil, true},
+		{"should not match nil and non nil", &protoComparer{}, nil, &errdetails.BadRequest{}, false},
+		{"should not match a typed nil and an empty message", &protoComparer{}, (*errdetails.BadRequest)(nil), &errdetails.BadRequest{}, false},
+		{"should not match different types", &protoComparer{}, &errdetails.BadRequest{}, &errdetails.ErrorInfo{}, false},
+		{"should match equal messages", &protoComparer{}, badRequest("a", "b"), badRequest("a", "b"), true},
+		{"should not match a different order", &protoComparer{}, badRequest("a", "b"), badRequest("b", "a"), false},
+		{"should match a different order ignoring the order", &protoComparer{ignoreRepeatedOrder: true}, badRequest("a", "b", "a"), badRequest("a", "a", "b"), true},
+		{"should match a different order of a given field", &protoComparer{unorderedFields: map[string]bool{"field_violations": true}}, badRequest("a", "b"), badRequest("b", "a"), true},
+		{"should not match different multisets", &protoComparer{ignoreRepeatedOrder: true}, badRequest("a", "b", "b"), badRequest("a", "a", "b"), false},
+		{"should match maps", &protoComparer{}, &errdetails.ErrorInfo{Metadata: map[string]string{"a": "1"}}, &errdetails.ErrorInfo{Metadata: map[string]string{"a": "1"}}, true},
+		{"should not match different maps", &protoComparer{}, &errdetails.ErrorInfo{Metadata: map[string]string{"a": "1"}}, &errdetails.ErrorInfo{Metadata: map[string]string{"a": "2"}}, false},
+		{"should match NaNs", &protoComparer{}, structpb.NewNumberValue(math.NaN()), structpb.NewNumberValue(math.NaN()), true},
+		{"should not match proto2 presence", &protoComparer{}, &descriptorpb.FileDescriptorProto{}, &descriptorpb.FileDescriptorProto{Name: proto.String("")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, tt.comparer.equal(tt.x, tt.y))
+		})
+	}
+}
+
+func TestProtoComparer_equal_nested(t *testing.T) {
+	x := &descriptorpb.FileDescriptorProto{
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("A"), Field: []*descriptorpb.FieldDescriptorProto{{Name: proto.String("a1")}, {Name: proto.String("a2")}}},
+			{Name: proto.String("B")},
+		},
+	}
+	y := &descriptorpb.FileDescriptorProto{
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("B")},
+			{Name: proto.String("A"), Field: []*descriptorpb.FieldDescriptorProto{{Name: proto.String("a2")}, {Name: proto.String("a1")}}},
+		},
+	}
+
+	assert.True(t, (&protoComparer{ignoreRepeatedOrder: true}).equal(x, y))
+	assert.False(t, (&protoComparer{unorderedFields: map[string]bool{"message_type": true}}).equal(x, y))
+	assert.True(t, (&protoComparer{unorderedFields: map[string]bool{"message_type": true, "message_type.field": true}}).equal(x, y))
+}
+
+func TestProtoComparer_reporting(t *testing.T) {
+	t.Run("should report the missing elements from each side", func(t *testing.T) {
+		comparer := &protoComparer{ignoreRepeatedOrder: true, reporting: true}
+		assert.False(t, comparer.equal(&structpb.ListValue{
+			Values: []*structpb.Value{structpb.NewStringValue("a"), structpb.NewStringValue("b")},
+		}, &structpb.ListValue{
+			Values: []*structpb.Value{structpb.NewStringValue("c"), structpb.NewStringValue("a")},
+		}))
+		require.Len(t, comparer.diffs, 1)
+		assert.Contains(t, comparer.diffs[0], "values: elements do not match regardless of the order")
+		assert.Contains(t, comparer.diffs[0], "missing from actual:\n      - \"b\"")
+		assert.Contains(t, comparer.diffs[0], "unexpected in actual:\n      - \"c\"")
+	})
+
+	t.Run("should report all differences", func(t *testing.T) {
+		comparer := &protoComparer{reporting: true}
+		assert.False(t, comparer.equal(&errdetails.ErrorInfo{
+			Reason: "reason1",
+			Domain: "domain1",
+		}, &errdetails.ErrorInfo{
+			Reason: "reason2",
+			Domain: "domain2",
+		}))
+		assert.Equal(t, []string{
+			`reason: "reason1" -> "reason2"`,
+			`domain: "domain1" -> "domain2"`,
+		}, comparer.diffs)
+	})
+
+	tests := []struct {
+		name      string
+		x, y      proto.Message
+		wantDiffs []string
+	}{
+		{
+			"should report nested fields and list elements",
+			&descriptorpb.FileDescriptorProto{
+				MessageType: []*descriptorpb.DescriptorProto{
+					{Name: proto.String("A")},
+					{Name: proto.String("B"), Field: []*descriptorpb.FieldDescriptorProto{{Name: proto.String("b1")}}},
+				},
+			},
+			&descriptorpb.FileDescriptorProto{
+				MessageType: []*descriptorpb.DescriptorProto{
+					{Name: proto.String("A")},
+					{Name: proto.String("B"), Field: []*descriptorpb.FieldDescriptorProto{{Name: proto.String("b2")}}},
+					{Name: proto.String("C")},
+				},
+			},
+			[]string{
+				`message_type[1].field[0].name: "b1" -> "b2"`,
+				`message_type[2]: added {"name":"C"}`,
+			},
+		},
+		{
+			"should tell unset fields from zero values",
+			&descriptorpb.FileDescriptorProto{Options: &descriptorpb.FileOptions{}},
+			&descriptorpb.FileDescriptorProto{Name: proto.String("")},
+			[]string{
+				`name: unset -> ""`,
+				`options: {} -> unset`,
+			},
+		},
+		{
+			"should report map entries sorted by key",
+			&errdetails.ErrorInfo{Metadata: map[string]string{"b": "1", "c": "2", "d": "3"}},
+			&errdetails.ErrorInfo{Metadata: map[string]string{"a": "0", "c": "2", "d": "4"}},
+			[]string{
+				`metadata["a"]: added "0"`,
+				`metadata["b"]: removed "1"`,
+				`metadata["d"]: "3" -> "4"`,
+			},
+		},
+		{
+			"should report switched oneofs",
+			&structpb.Struct{Fields: map[string]*structpb.Value{"key": structpb.NewStringValue("a")}},
+			&structpb.Struct{Fields: map[string]*structpb.Value{"key": structpb.NewNumberValue(1)}},
+			[]string{
+				`oneof fields["key"].kind: switched from string_value = "a" to number_value = 1`,
+			},
+		},
+		{
+			"should report different types",
+			&errdetails.ErrorInfo{},
+			&errdetails.BadRequest{},
+			[]string{
+				`<root>: type google.rpc.ErrorInfo -> google.rpc.BadRequest`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparer := &protoComparer{reporting: true}
+			assert.False(t, comparer.equal(tt.x, tt.y))
+			assert.Equal(t, tt.wantDiffs, comparer.diffs)
+		})
+	}
+}
+
+func TestProtoComparer_equal_floats(t *testing.T) {
+	list := func(values ...float64) *structpb.ListValue {
+		l := &structpb.ListValue{}
+		for _, v := range values {
+			l.Values = append(l.Values, structpb.NewNumberValue(v))
+		}
+		return l
+	}
+
+	tests := []struct {
+		name      string
+		comparer  *protoComparer
+		x, y      proto.Message
+		wantEqual bool
+	}{
+		{"should not match without tolerance", &protoComparer{}, wrapperspb.Double(1), wrapperspb.Double(1.0001), false},
+		{"should match wrappers within the absolute tolerance", &protoComparer{floatTolerance: &FloatTolerance{Absolute: 0.001}}, wrapperspb.Double(1), wrapperspb.Double(1.0001), true},
+		{"should match float wrappers within the absolute tolerance", &protoComparer{floatTolerance: &FloatTolerance{Absolute: 0.001}}, wrapperspb.Float(1), wrapperspb.Float(1.0001), true},
+		{"should match repeated values within the relative tolerance", &protoComparer{floatTolerance: &FloatTolerance{Relative: 0.01}}, list(100, 1000), list(100.5, 1009), true},
+		{"should not match repeated values out of the relative tolerance", &protoComparer{floatTolerance: &FloatTolerance{Relative: 0.01}}, list(100, 1000), list(100.5, 1011), false},
+		{"should match NaNs without tolerance", &protoComparer{}, wrapperspb.Double(math.NaN()), wrapperspb.Double(math.NaN()), true},
+		{"should not match NaNs with tolerance", &protoComparer{floatTolerance: &FloatTolerance{Absolute: 1}}, wrapperspb.Double(math.NaN()), wrapperspb.Double(math.NaN()), false},
+		{"should match NaNs with tolerance equating NaNs", &protoComparer{floatTolerance: &FloatTolerance{Absolute: 1}, equateNaNs: true}, wrapperspb.Double(math.NaN()), wrapperspb.Double(math.NaN()), true},
+		{"should match infinities", &protoComparer{floatTolerance: &FloatTolerance{Relative: 1}}, wrapperspb.Double(math.Inf(1)), wrapperspb.Double(math.Inf(1)), true},
+		{"should not match infinity with a finite value", &protoComparer{floatTolerance: &FloatTolerance{Relative: 1}}, wrapperspb.Double(math.Inf(1)), wrapperspb.Double(math.MaxFloat64), false},
+		{"should use the field tolerance", &protoComparer{floatTolerance: &FloatTolerance{Absolute: 0.1}, fieldFloatTolerances: map[string]FloatTolerance{"values": {}}}, list(1), list(1.01), false},
+		{"should pair the unordered values within the tolerance", &protoComparer{ignoreRepeatedOrder: true, floatTolerance: &FloatTolerance{Absolute: 0.05}}, list(1.0, 1.05), list(1.04, 0.96), true},
+		{"should not pair the unordered values out of the tolerance", &protoComparer{ignoreRepeatedOrder: true, floatTolerance: &FloatTolerance{Absolute: 0.05}}, list(1.0, 1.05), list(1.04, 0.9), false},
+		{"should use the field tolerance for nested fields", &protoComparer{fieldFloatTolerances: map[string]FloatTolerance{"values": {Absolute: 0.1}}}, list(1), list(1.01), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, tt.comparer.equal(tt.x, tt.y))
+		})
+	}
+}
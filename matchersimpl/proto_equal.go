@@ -2,8 +2,10 @@ package matchersimpl
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 
-	"github.com/andreyvit/diff"
 	"github.com/onsi/gomega/format"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -14,8 +16,44 @@ var (
 	errProtoEqualActualNotMessage = errors.New("The given actual value is not a proto.Message")                                                                                                                        // nolint // This is the gomega standard.
 )
 
+// ProtoEqualMatcher implements a gomega.Matcher that compares proto.Message values using a protoComparer, which has
+// the same semantics of proto.Equal, extended by the options below, and reports each difference by its field path.
 type ProtoEqualMatcher struct {
 	Expected proto.Message
+	// IgnoredFields is a list of field paths (eg: `metadata.create_time` or `items[*].etag`) that are left out of the
+	// comparison. The paths are checked against the Expected descriptor.
+	IgnoredFields []string
+	// IgnoreRepeatedOrder compares all repeated fields, at any depth, as multisets.
+	IgnoreRepeatedOrder bool
+	// UnorderedFields is a list of paths of repeated fields (eg: `items` or `items[*].tags`) that are compared as
+	// multisets.
+	UnorderedFields []string
+	// FloatTolerance, when set, is the tolerance used to compare all the float and double fields.
+	FloatTolerance *FloatTolerance
+	// FieldFloatTolerances maps field paths to the tolerance used to compare the float and double fields at, or under,
+	// them. It takes precedence over FloatTolerance.
+	FieldFloatTolerances map[string]FloatTolerance
+	// EquateNaNs makes NaN equal to NaN when comparing float and double fields with a tolerance. Without a tolerance,
+	// NaNs are always equal, as in proto.Equal.
+	EquateNaNs bool
+}
+
+// FloatTolerance defines how much two float or double values can differ and still be considered equal. Values are
+// equal when their difference is within Absolute, or within Relative times the greatest of their magnitudes.
+type FloatTolerance struct {
+	Absolute float64
+	Relative float64
+}
+
+func (t FloatTolerance) equal(x, y float64) bool {
+	if x == y {
+		return true
+	}
+	if math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false
+	}
+	d := math.Abs(x - y)
+	return d <= t.Absolute || d <= t.Relative*math.Max(math.Abs(x), math.Abs(y))
 }
 
 func (matcher *ProtoEqualMatcher) Match(actual interface{}) (success bool, err error) {
@@ -26,20 +64,129 @@ func (matcher *ProtoEqualMatcher) Match(actual interface{}) (success bool, err e
 	if !ok {
 		return false, errProtoEqualActualNotMessage
 	}
-	return proto.Equal(actualProtoMessage, matcher.Expected), nil
+	actualProtoMessage, expected, err := matcher.prepare(actualProtoMessage)
+	if err != nil {
+		return false, err
+	}
+	comparer, err := matcher.comparer()
+	if err != nil {
+		return false, err
+	}
+	return comparer.equal(expected, actualProtoMessage), nil
+}
+
+// comparer builds the protoComparer configured by the matcher options.
+func (matcher *ProtoEqualMatcher) comparer() (*protoComparer, error) {
+	comparer := &protoComparer{
+		ignoreRepeatedOrder: matcher.IgnoreRepeatedOrder,
+		floatTolerance:      matcher.FloatTolerance,
+		equateNaNs:          matcher.EquateNaNs,
+	}
+	if matcher.Expected == nil {
+		return comparer, nil
+	}
+	md := matcher.Expected.ProtoReflect().Descriptor()
+	if len(matcher.UnorderedFields) > 0 {
+		comparer.unorderedFields = make(map[string]bool, len(matcher.UnorderedFields))
+		for _, p := range matcher.UnorderedFields {
+			path, err := compileFieldPath(md, p)
+			if err != nil {
+				return nil, err
+			}
+			if !path[len(path)-1].field.IsList() {
+				return nil, fmt.Errorf("%w %q: %s is not a repeated field", errInvalidFieldPath, p, path[len(path)-1].field.Name())
+			}
+			comparer.unorderedFields[path.key()] = true
+		}
+	}
+	if len(matcher.FieldFloatTolerances) > 0 {
+		comparer.fieldFloatTolerances = make(map[string]FloatTolerance, len(matcher.FieldFloatTolerances))
+		for p, tolerance := range matcher.FieldFloatTolerances {
+			path, err := compileFieldPath(md, p)
+			if err != nil {
+				return nil, err
+			}
+			comparer.fieldFloatTolerances[path.key()] = tolerance
+		}
+	}
+	return comparer, nil
+}
+
+// prepare returns copies of actual and Expected without the IgnoredFields. If there is no field to be ignored, actual
+// and Expected are returned as they are.
+func (matcher *ProtoEqualMatcher) prepare(actual proto.Message) (proto.Message, proto.Message, error) {
+	if len(matcher.IgnoredFields) == 0 || matcher.Expected == nil {
+		return actual, matcher.Expected, nil
+	}
+	md := matcher.Expected.ProtoReflect().Descriptor()
+	paths := make([]fieldPath, 0, len(matcher.IgnoredFields))
+	for _, p := range matcher.IgnoredFields {
+		path, err := compileFieldPath(md, p)
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = append(paths, path)
+	}
+	expected := clearFieldPaths(matcher.Expected, paths)
+	if actual != nil && actual.ProtoReflect().Descriptor().FullName() == md.FullName() {
+		actual = clearFieldPaths(actual, paths)
+	}
+	return actual, expected, nil
+}
+
+// clearFieldPaths returns a copy of m with all the given paths cleared.
+func clearFieldPaths(m proto.Message, paths []fieldPath) proto.Message {
+	if !m.ProtoReflect().IsValid() {
+		return m
+	}
+	m = proto.Clone(m)
+	for _, path := range paths {
+		path.clear(m.ProtoReflect())
+	}
+	return m
 }
 
 func (matcher *ProtoEqualMatcher) FailureMessage(actual interface{}) (message string) {
 	if pactual, ok := actual.(proto.Message); ok {
-		return format.Message(protojson.Format(pactual), "to equal", protojson.Format(matcher.Expected)) +
-			"\n\nDiff:\n" + diff.LineDiff(protojson.Format(matcher.Expected), protojson.Format(pactual))
+		pactual, expected, err := matcher.prepare(pactual)
+		if err != nil {
+			return format.Message(actual, "to equal", matcher.Expected) + "\n\n" + err.Error()
+		}
+		return format.Message(protojson.Format(pactual), "to equal", protojson.Format(expected)) +
+			"\n\nDifferences:\n" + matcher.differences(expected, pactual)
 	}
 	return format.Message(actual, "to equal", matcher.Expected)
 }
 
+// differences lists the differences found between expected and actual, one per line, as `path: expected -> actual`.
+// The list is truncated when it exceeds format.MaxLength.
+func (matcher *ProtoEqualMatcher) differences(expected, actual proto.Message) string {
+	comparer, err := matcher.comparer()
+	if err != nil {
+		return err.Error()
+	}
+	comparer.reporting = true
+	comparer.equal(expected, actual)
+	lines := make([]string, 0, len(comparer.diffs))
+	length := 0
+	for i, d := range comparer.diffs {
+		length += len(d)
+		if format.MaxLength > 0 && length > format.MaxLength {
+			lines = append(lines, fmt.Sprintf("...%d more differences omitted, the list exceeds format.MaxLength", len(comparer.diffs)-i))
+			break
+		}
+		lines = append(lines, d)
+	}
+	return "  " + strings.Join(lines, "\n  ")
+}
+
 func (matcher *ProtoEqualMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	if pactual, ok := actual.(proto.Message); ok {
-		return format.Message(protojson.Format(pactual), "not to equal", protojson.Format(matcher.Expected))
+		pactual, expected, err := matcher.prepare(pactual)
+		if err != nil {
+			return format.Message(actual, "not to equal", matcher.Expected) + "\n\n" + err.Error()
+		}
+		return format.Message(protojson.Format(pactual), "not to equal", protojson.Format(expected))
 	}
 	return format.Message(actual, "not to equal", matcher.Expected)
 }
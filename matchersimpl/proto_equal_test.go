@@ -2,21 +2,27 @@ package matchersimpl
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/onsi/gomega/format"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestProtoEqualMatcher_Match(t *testing.T) {
 	t.Run("should fail when expected and actual is nil", func(t *testing.T) {
-		gotMatch, err := (&ProtoEqualMatcher{nil}).Match(nil)
+		gotMatch, err := (&ProtoEqualMatcher{Expected: nil}).Match(nil)
 		assert.False(t, gotMatch)
 		assert.ErrorIs(t, err, errProtoEqualNil)
 	})
 
 	t.Run("should fail when actual is not a proto.Message", func(t *testing.T) {
-		gotMatch, err := (&ProtoEqualMatcher{nil}).Match(false)
+		gotMatch, err := (&ProtoEqualMatcher{Expected: nil}).Match(false)
 		assert.False(t, gotMatch)
 		assert.ErrorIs(t, err, errProtoEqualActualNotMessage)
 	})
@@ -35,9 +41,29 @@ func TestProtoEqualMatcher_FailureMessage(t *testing.T) {
 		assert.Contains(t, gotMessage, "to equal")
 		assert.Contains(t, gotMessage, "message 1")
 		assert.Contains(t, gotMessage, "message 2")
+		assert.Contains(t, gotMessage, "Differences:\n  message: \"message 1\" -> \"message 2\"")
+	})
+	t.Run("when the differences exceed format.MaxLength", func(t *testing.T) {
+		defer func(maxLength int) {
+			format.MaxLength = maxLength
+		}(format.MaxLength)
+		format.MaxLength = 100
+
+		expected, actual := &errdetails.ErrorInfo{}, &errdetails.ErrorInfo{}
+		expected.Metadata, actual.Metadata = map[string]string{}, map[string]string{}
+		for i := 0; i < 10; i++ {
+			expected.Metadata[fmt.Sprintf("key%d", i)] = "expected value"
+			actual.Metadata[fmt.Sprintf("key%d", i)] = "actual value"
+		}
+		gotMessage := (&ProtoEqualMatcher{
+			Expected: expected,
+		}).FailureMessage(actual)
+		assert.Contains(t, gotMessage, `metadata["key0"]: "expected value" -> "actual value"`)
+		assert.NotContains(t, gotMessage, `metadata["key9"]`)
+		assert.Contains(t, gotMessage, "more differences omitted")
 	})
 	t.Run("when actual is NOT a proto.Message", func(t *testing.T) {
-		gotMessage := (&ProtoEqualMatcher{nil}).FailureMessage("string")
+		gotMessage := (&ProtoEqualMatcher{Expected: nil}).FailureMessage("string")
 		assert.Contains(t, gotMessage, "to equal")
 		assert.Contains(t, gotMessage, "string")
 		assert.Contains(t, gotMessage, "nil")
@@ -45,8 +71,138 @@ func TestProtoEqualMatcher_FailureMessage(t *testing.T) {
 }
 
 func TestProtoEqualMatcher_NegatedFailureMessage(t *testing.T) {
-	gotMessage := (&ProtoEqualMatcher{nil}).NegatedFailureMessage("string")
+	gotMessage := (&ProtoEqualMatcher{Expected: nil}).NegatedFailureMessage("string")
 	assert.Contains(t, gotMessage, "not to equal")
 	assert.Contains(t, gotMessage, "string")
 	assert.Contains(t, gotMessage, "nil")
 }
+
+func TestProtoEqualMatcher_IgnoredFields(t *testing.T) {
+	expected := &descriptorpb.FileDescriptorProto{
+		Name: proto.String("file.proto"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Message1")},
+		},
+	}
+	actual := &descriptorpb.FileDescriptorProto{
+		Name: proto.String("other.proto"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Message2")},
+		},
+	}
+
+	t.Run("should match ignoring the fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:      expected,
+			IgnoredFields: []string{"name", "message_type[*].name"},
+		}).Match(actual)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+		assert.Equal(t, "file.proto", expected.GetName(), "expected should not be changed")
+		assert.Equal(t, "other.proto", actual.GetName(), "actual should not be changed")
+	})
+
+	t.Run("should not match when a not ignored field is different", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:      expected,
+			IgnoredFields: []string{"name"},
+		}).Match(actual)
+		assert.NoError(t, err)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail with an invalid path", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:      expected,
+			IgnoredFields: []string{"nmae"},
+		}).Match(actual)
+		assert.ErrorIs(t, err, errInvalidFieldPath)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should leave ignored fields out of the failure message", func(t *testing.T) {
+		gotMessage := (&ProtoEqualMatcher{
+			Expected:      expected,
+			IgnoredFields: []string{"name"},
+		}).FailureMessage(actual)
+		assert.NotContains(t, gotMessage, "file.proto")
+		assert.NotContains(t, gotMessage, "other.proto")
+		assert.Contains(t, gotMessage, "Message1")
+		assert.Contains(t, gotMessage, "Message2")
+	})
+}
+
+func TestProtoEqualMatcher_UnorderedFields(t *testing.T) {
+	expected := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "field1"},
+			{Field: "field2"},
+		},
+	}
+	actual := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "field2"},
+			{Field: "field3"},
+		},
+	}
+
+	t.Run("should fail when the path is not a repeated field", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:        expected,
+			UnorderedFields: []string{"field_violations[*].field"},
+		}).Match(actual)
+		assert.ErrorIs(t, err, errInvalidFieldPath)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should list the missing elements in the failure message", func(t *testing.T) {
+		gotMessage := (&ProtoEqualMatcher{
+			Expected:        expected,
+			UnorderedFields: []string{"field_violations"},
+		}).FailureMessage(actual)
+		assert.Contains(t, gotMessage, "Differences:")
+		assert.Contains(t, gotMessage, "missing from actual:")
+		assert.Contains(t, gotMessage, "unexpected in actual:")
+	})
+}
+
+func TestFloatTolerance_equal(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance FloatTolerance
+		x, y      float64
+		wantEqual bool
+	}{
+		{"should match equal values", FloatTolerance{}, 1, 1, true},
+		{"should match within the absolute tolerance", FloatTolerance{Absolute: 0.5}, 1, 1.5, true},
+		{"should not match out of the absolute tolerance", FloatTolerance{Absolute: 0.5}, 1, 1.6, false},
+		{"should match within the relative tolerance", FloatTolerance{Relative: 0.1}, -10, -11, true},
+		{"should not match out of the relative tolerance", FloatTolerance{Relative: 0.1}, 10, 11.2, false},
+		{"should not match different infinities", FloatTolerance{Absolute: math.MaxFloat64}, math.Inf(1), math.Inf(-1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, tt.tolerance.equal(tt.x, tt.y))
+		})
+	}
+}
+
+func TestProtoEqualMatcher_FieldFloatTolerances(t *testing.T) {
+	t.Run("should fail with an invalid path", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:             &structpb.ListValue{},
+			FieldFloatTolerances: map[string]FloatTolerance{"value": {Absolute: 1}},
+		}).Match(&structpb.ListValue{})
+		assert.ErrorIs(t, err, errInvalidFieldPath)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should match within the field tolerance", func(t *testing.T) {
+		gotMatch, err := (&ProtoEqualMatcher{
+			Expected:             &structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(1)}},
+			FieldFloatTolerances: map[string]FloatTolerance{"values[*].number_value": {Absolute: 0.1}},
+		}).Match(&structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(1.05)}})
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+}
@@ -0,0 +1,201 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	errUnknownProtoField    = errors.New("unknown proto fields")
+	errNilProtoFieldMatcher = errors.New("nil proto field matchers")
+)
+
+// ProtoFields maps proto field names to the matchers applied to their values.
+type ProtoFields map[string]types.GomegaMatcher
+
+// ProtoFieldsMatcher matches a proto.Message by applying a matcher to each of the given fields. Field names are
+// resolved using the message descriptor, so the proto (or JSON) names must be used instead of the Go names.
+//
+// The values given to the matchers are Go values: scalars have their Go types, enums have their generated types,
+// messages are proto.Message, repeated fields are slices and map fields are maps.
+//
+// If the matcher of a singular message field is also a ProtoFieldsMatcher, it is matched against the nested message
+// and its failures are reported with the full path of the fields.
+type ProtoFieldsMatcher struct {
+	Fields ProtoFields
+	// IgnoreExtras ignores populated fields that are not present on Fields. Otherwise, they fail the match.
+	IgnoreExtras bool
+	// IgnoreMissing skips the matchers of the fields that are not populated.
+	IgnoreMissing bool
+
+	failures []string
+}
+
+func (matcher *ProtoFieldsMatcher) Match(actual interface{}) (success bool, err error) {
+	actualProtoMessage, ok := actual.(proto.Message)
+	if !ok {
+		return false, errProtoEqualActualNotMessage
+	}
+	m := actualProtoMessage.ProtoReflect()
+	if err := matcher.Validate(m.Descriptor()); err != nil {
+		return false, err
+	}
+	matcher.failures = matcher.matchFields("", m)
+	return len(matcher.failures) == 0, nil
+}
+
+// Validate checks if all the Fields, including the ones of nested ProtoFieldsMatcher on singular message fields, exist
+// in the given descriptor and have a matcher. Otherwise, it returns an error listing the invalid fields.
+func (matcher *ProtoFieldsMatcher) Validate(md protoreflect.MessageDescriptor) error {
+	unknown, nilMatchers := matcher.invalidFields("", md)
+	if len(nilMatchers) > 0 {
+		return fmt.Errorf("%w for %s: %s", errNilProtoFieldMatcher, md.FullName(), strings.Join(nilMatchers, ", "))
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w for %s: %s", errUnknownProtoField, md.FullName(), strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// invalidFields returns the paths of all the fields, including the ones of nested ProtoFieldsMatcher, that do not
+// exist in the given descriptor and the paths of the fields whose matchers are nil.
+func (matcher *ProtoFieldsMatcher) invalidFields(prefix string, md protoreflect.MessageDescriptor) (unknown, nilMatchers []string) {
+	for _, name := range matcher.names() {
+		if matcher.Fields[name] == nil {
+			nilMatchers = append(nilMatchers, prefix+name)
+		}
+		fd := findField(md, name)
+		if fd == nil {
+			unknown = append(unknown, prefix+name)
+			continue
+		}
+		if nested, ok := matcher.Fields[name].(*ProtoFieldsMatcher); ok && isSingularMessage(fd) {
+			nestedUnknown, nestedNilMatchers := nested.invalidFields(joinFieldPath(prefix, fd)+".", fd.Message())
+			unknown = append(unknown, nestedUnknown...)
+			nilMatchers = append(nilMatchers, nestedNilMatchers...)
+		}
+	}
+	return unknown, nilMatchers
+}
+
+func (matcher *ProtoFieldsMatcher) matchFields(prefix string, m protoreflect.Message) []string {
+	var failures []string
+	matched := make(map[protoreflect.FieldNumber]bool, len(matcher.Fields))
+	for _, name := range matcher.names() {
+		fd := findField(m.Descriptor(), name)
+		matched[fd.Number()] = true
+		path := joinFieldPath(prefix, fd)
+		if matcher.IgnoreMissing && !m.Has(fd) {
+			continue
+		}
+		fieldMatcher := matcher.Fields[name]
+		if nested, ok := fieldMatcher.(*ProtoFieldsMatcher); ok && isSingularMessage(fd) {
+			failures = append(failures, nested.matchFields(path, m.Get(fd).Message())...)
+			continue
+		}
+		value := protoFieldValue(m, fd)
+		success, err := fieldMatcher.Match(value)
+		switch {
+		case err != nil:
+			failures = append(failures, formatFieldFailure(path, err.Error()))
+		case !success:
+			failures = append(failures, formatFieldFailure(path, fieldMatcher.FailureMessage(value)))
+		}
+	}
+	if !matcher.IgnoreExtras {
+		m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fd.IsExtension() || !matched[fd.Number()] {
+				failures = append(failures, formatFieldFailure(joinFieldPath(prefix, fd), format.Message(protoFieldValue(m, fd), "is an unexpected populated field")))
+			}
+			return true
+		})
+	}
+	return failures
+}
+
+// names returns the field names sorted, so the failures are always reported in the same order.
+func (matcher *ProtoFieldsMatcher) names() []string {
+	names := make([]string, 0, len(matcher.Fields))
+	for name := range matcher.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (matcher *ProtoFieldsMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(formatActualMessage(actual), "to match proto fields") + "\n" + strings.Join(matcher.failures, "\n")
+}
+
+func (matcher *ProtoFieldsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(formatActualMessage(actual), "not to match proto fields")
+}
+
+// formatActualMessage renders the actual proto.Message with formatMessage, instead of the internals of its struct.
+// Other values are returned as they are.
+func formatActualMessage(actual interface{}) interface{} {
+	if m, ok := actual.(proto.Message); ok {
+		return formatMessage(m)
+	}
+	return actual
+}
+
+func formatFieldFailure(path, message string) string {
+	return "  " + path + ":\n" + format.IndentString(message, 1)
+}
+
+func isSingularMessage(fd protoreflect.FieldDescriptor) bool {
+	return fd.Message() != nil && !fd.IsList() && !fd.IsMap()
+}
+
+// protoFieldValue returns the value of the field fd of m as a Go value. Repeated fields are returned as slices and map
+// fields as maps, both typed after their elements.
+func protoFieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) interface{} {
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		list := v.List()
+		result := reflect.MakeSlice(reflect.SliceOf(protoGoValue(fd, list.NewElement()).Type()), 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			result = reflect.Append(result, protoGoValue(fd, list.Get(i)))
+		}
+		return result.Interface()
+	case fd.IsMap():
+		mp := v.Map()
+		keyType := reflect.TypeOf(fd.MapKey().Default().Interface())
+		valueType := protoGoValue(fd.MapValue(), mp.NewValue()).Type()
+		result := reflect.MakeMapWithSize(reflect.MapOf(keyType, valueType), mp.Len())
+		mp.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			result.SetMapIndex(reflect.ValueOf(k.Interface()), protoGoValue(fd.MapValue(), v))
+			return true
+		})
+		return result.Interface()
+	default:
+		return protoGoValue(fd, v).Interface()
+	}
+}
+
+// protoGoValue converts a singular value to its Go representation. Enums are converted to their generated types, when
+// they are registered.
+func protoGoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) reflect.Value {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if et, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName()); err == nil {
+			return reflect.ValueOf(et.New(v.Enum()))
+		}
+		return reflect.ValueOf(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return reflect.ValueOf(v.Message().Interface())
+	default:
+		return reflect.ValueOf(v.Interface())
+	}
+}
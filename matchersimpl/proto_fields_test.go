@@ -0,0 +1,205 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestProtoFieldsMatcher_Match(t *testing.T) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String("id"),
+		Number: proto.Int32(1),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+		Options: &descriptorpb.FieldOptions{
+			Deprecated: proto.Bool(true),
+		},
+	}
+
+	t.Run("should fail when actual is not a proto.Message", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{}).Match("string")
+		assert.ErrorIs(t, err, errProtoEqualActualNotMessage)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail with all the unknown fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"nmae": gomega.Equal("id"),
+				"options": &ProtoFieldsMatcher{
+					Fields: ProtoFields{"deprecatd": gomega.BeTrue()},
+				},
+			},
+		}).Match(field)
+		assert.ErrorIs(t, err, errUnknownProtoField)
+		assert.Contains(t, err.Error(), "nmae, options.deprecatd")
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail with the nil matchers instead of panicking", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{Fields: ProtoFields{"name": nil}}).Match(field)
+		assert.ErrorIs(t, err, errNilProtoFieldMatcher)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should match all the fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"name":   gomega.Equal("id"),
+				"number": gomega.BeNumerically(">", 0),
+				"label":  gomega.Equal(descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+				"options": &ProtoFieldsMatcher{
+					Fields: ProtoFields{"deprecated": gomega.BeTrue()},
+				},
+			},
+		}).Match(field)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+
+	t.Run("should not match extra fields", func(t *testing.T) {
+		matcher := &ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"name": gomega.Equal("id"),
+			},
+		}
+		gotMatch, err := matcher.Match(field)
+		assert.NoError(t, err)
+		assert.False(t, gotMatch)
+		assert.Len(t, matcher.failures, 3)
+	})
+
+	t.Run("should ignore extra fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"name": gomega.Equal("id"),
+			},
+			IgnoreExtras: true,
+		}).Match(field)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+
+	t.Run("should ignore missing fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"type_name": gomega.Equal("something"),
+			},
+			IgnoreExtras:  true,
+			IgnoreMissing: true,
+		}).Match(field)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+
+	t.Run("should match repeated and map fields", func(t *testing.T) {
+		gotMatch, err := (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"field_violations": gomega.HaveLen(1),
+			},
+		}).Match(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "field1"}},
+		})
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+
+		gotMatch, err = (&ProtoFieldsMatcher{
+			Fields: ProtoFields{
+				"metadata": gomega.HaveKeyWithValue("key", "value"),
+			},
+		}).Match(&errdetails.ErrorInfo{
+			Metadata: map[string]string{"key": "value"},
+		})
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+}
+
+func TestProtoFieldsMatcher_Validate(t *testing.T) {
+	md := (&descriptorpb.FieldDescriptorProto{}).ProtoReflect().Descriptor()
+
+	t.Run("should accept known fields", func(t *testing.T) {
+		assert.NoError(t, (&ProtoFieldsMatcher{Fields: ProtoFields{
+			"name":    gomega.Equal("id"),
+			"options": &ProtoFieldsMatcher{Fields: ProtoFields{"deprecated": gomega.BeTrue()}},
+		}}).Validate(md))
+	})
+
+	t.Run("should reject unknown fields, including nested ones", func(t *testing.T) {
+		err := (&ProtoFieldsMatcher{Fields: ProtoFields{
+			"nam":     gomega.Equal("id"),
+			"options": &ProtoFieldsMatcher{Fields: ProtoFields{"deprecatd": gomega.BeTrue()}},
+		}}).Validate(md)
+		assert.ErrorIs(t, err, errUnknownProtoField)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nam, options.deprecatd")
+	})
+
+	t.Run("should reject nil matchers, including nested ones", func(t *testing.T) {
+		err := (&ProtoFieldsMatcher{Fields: ProtoFields{
+			"name":    nil,
+			"options": &ProtoFieldsMatcher{Fields: ProtoFields{"deprecated": nil}},
+		}}).Validate(md)
+		assert.ErrorIs(t, err, errNilProtoFieldMatcher)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "name, options.deprecated")
+	})
+}
+
+func TestProtoFieldsMatcher_FailureMessage(t *testing.T) {
+	matcher := &ProtoFieldsMatcher{
+		Fields: ProtoFields{
+			"name": gomega.Equal("name"),
+			"options": &ProtoFieldsMatcher{
+				Fields: ProtoFields{"deprecated": gomega.BeFalse()},
+			},
+		},
+	}
+	actual := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String("id"),
+		Number: proto.Int32(1),
+		Options: &descriptorpb.FieldOptions{
+			Deprecated: proto.Bool(true),
+		},
+	}
+	gotMatch, err := matcher.Match(actual)
+	require.NoError(t, err)
+	require.False(t, gotMatch)
+
+	gotMessage := matcher.FailureMessage(actual)
+	assert.Contains(t, gotMessage, "to match proto fields")
+	assert.Contains(t, gotMessage, "  name:\n")
+	assert.Contains(t, gotMessage, "  options.deprecated:\n")
+	assert.Contains(t, gotMessage, "  number:\n")
+	assert.Contains(t, gotMessage, "is an unexpected populated field")
+	assert.Contains(t, gotMessage, `{"name":"id","number":1,"options":{"deprecated":true}}`)
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
+
+func TestProtoFieldsMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&ProtoFieldsMatcher{}).NegatedFailureMessage(&errdetails.ErrorInfo{Reason: "reason"})
+	assert.Contains(t, gotMessage, "not to match proto fields")
+	assert.Contains(t, gotMessage, `{"reason":"reason"}`)
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
+
+func Test_protoFieldValue(t *testing.T) {
+	m := (&descriptorpb.EnumDescriptorProto{}).ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	assert.Equal(t, []*descriptorpb.EnumValueDescriptorProto{}, protoFieldValue(m, fields.ByName("value")))
+	assert.Equal(t, []string{}, protoFieldValue(m, fields.ByName("reserved_name")))
+	assert.Equal(t, "", protoFieldValue(m, fields.ByName("name")))
+	assert.Equal(t, (*descriptorpb.EnumOptions)(nil), protoFieldValue(m, fields.ByName("options")))
+
+	fm := (&descriptorpb.FieldDescriptorProto{}).ProtoReflect()
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, protoFieldValue(fm, fm.Descriptor().Fields().ByName("type")))
+
+	em := (&errdetails.ErrorInfo{}).ProtoReflect()
+	assert.Equal(t, map[string]string{}, protoFieldValue(em, em.Descriptor().Fields().ByName("metadata")))
+}
@@ -0,0 +1,161 @@
+package matchersimpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a true value (eg: `1`), makes the ProtoGoldenMatcher
+// rewrite the golden files with the actual values.
+const UpdateGoldenEnv = "GOMEGA_GRPC_UPDATE_GOLDEN"
+
+var (
+	errGoldenFile = errors.New("golden file")
+)
+
+// ProtoGoldenMatcher is a ProtoEqualMatcher whose expected message is stored in a golden file. Files with the `.json`
+// extension are in the protobuf JSON format, any other extension is in the protobuf text format.
+//
+// Golden files are written in a stable format, so they only change when the message does. Text files start with the
+// `# proto-file` and `# proto-message` header comments, naming the message type for editors and other tools.
+//
+// When the UpdateGoldenEnv environment variable is set, the golden file is written with the actual message before the
+// comparison.
+type ProtoGoldenMatcher struct {
+	ProtoEqualMatcher
+	Path string
+}
+
+func (matcher *ProtoGoldenMatcher) Match(actual interface{}) (success bool, err error) {
+	actualProtoMessage, ok := actual.(proto.Message)
+	if !ok {
+		return false, errProtoEqualActualNotMessage
+	}
+	if updateGolden() {
+		if err := matcher.write(actualProtoMessage); err != nil {
+			return false, err
+		}
+	}
+	if err := matcher.read(actualProtoMessage); err != nil {
+		return false, err
+	}
+	return matcher.ProtoEqualMatcher.Match(actual)
+}
+
+func (matcher *ProtoGoldenMatcher) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(matcher.Path), ".json")
+}
+
+// read parses the golden file into a new message of the same type of actual and sets it as Expected.
+func (matcher *ProtoGoldenMatcher) read(actual proto.Message) error {
+	data, err := os.ReadFile(matcher.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w %s does not exist, run the tests with %s=1 to create it", errGoldenFile, matcher.Path, UpdateGoldenEnv)
+	}
+	if err != nil {
+		return fmt.Errorf("%w %s: %v", errGoldenFile, matcher.Path, err)
+	}
+	expected := actual.ProtoReflect().New().Interface()
+	if matcher.isJSON() {
+		err = protojson.Unmarshal(data, expected)
+	} else {
+		err = prototext.Unmarshal(data, expected)
+	}
+	if err != nil {
+		return fmt.Errorf("%w %s: %v", errGoldenFile, matcher.Path, err)
+	}
+	matcher.Expected = expected
+	return nil
+}
+
+// write stores actual into the golden file, creating its directory if needed.
+func (matcher *ProtoGoldenMatcher) write(actual proto.Message) error {
+	var (
+		data []byte
+		err  error
+	)
+	if matcher.isJSON() {
+		data, err = protojson.Marshal(actual)
+		if err == nil {
+			// json.Indent drops the random spaces protojson adds to make its output unstable.
+			var buf bytes.Buffer
+			err = json.Indent(&buf, data, "", "  ")
+			data = buf.Bytes()
+		}
+	} else {
+		data, err = prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(actual)
+		if err == nil {
+			data = append(textprotoHeader(actual), normalizeTextproto(data)...)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w %s: %v", errGoldenFile, matcher.Path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(matcher.Path), 0o755); err != nil {
+		return fmt.Errorf("%w %s: %v", errGoldenFile, matcher.Path, err)
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	if err := os.WriteFile(matcher.Path, data, 0o644); err != nil {
+		return fmt.Errorf("%w %s: %v", errGoldenFile, matcher.Path, err)
+	}
+	return nil
+}
+
+// textprotoHeader returns the header comments naming the proto file and the message type of m, following the
+// textproto conventions.
+func textprotoHeader(m proto.Message) []byte {
+	md := m.ProtoReflect().Descriptor()
+	return []byte(fmt.Sprintf("# proto-file: %s\n# proto-message: %s\n\n", md.ParentFile().Path(), md.FullName()))
+}
+
+// normalizeTextproto removes the random extra space that prototext adds after the field names, to make its output
+// unstable. prototext writes a field per line, starting with its name and a colon, and escapes the line breaks of the
+// strings. So only the spaces right after the first token of the line are changed, when that token is a field name,
+// and the values, including the spaces inside their strings, are kept as they are.
+func normalizeTextproto(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		content := bytes.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		space := bytes.IndexByte(content, ' ')
+		if space < 1 || !isTextprotoFieldName(content[:space]) || !bytes.HasPrefix(content[space:], []byte("  ")) {
+			continue
+		}
+		normalized := append([]byte{}, line[:indent+space+1]...)
+		lines[i] = append(normalized, content[space+2:]...)
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// isTextprotoFieldName checks if the token is a field name followed by its colon (eg: reason: or [pkg.ext]:), which
+// is never part of a quoted string.
+func isTextprotoFieldName(token []byte) bool {
+	return bytes.HasSuffix(token, []byte(":")) && !bytes.ContainsAny(token, `"'`)
+}
+
+func (matcher *ProtoGoldenMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.ProtoEqualMatcher.FailureMessage(actual) +
+		fmt.Sprintf("\n\nGolden file: %s (run the tests with %s=1 to update it)", matcher.Path, UpdateGoldenEnv)
+}
+
+func (matcher *ProtoGoldenMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.ProtoEqualMatcher.NegatedFailureMessage(actual) + fmt.Sprintf("\n\nGolden file: %s", matcher.Path)
+}
+
+// updateGolden checks if the UpdateGoldenEnv environment variable is set to a true value.
+func updateGolden() bool {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	return update
+}
@@ -0,0 +1,177 @@
+package matchersimpl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestProtoGoldenMatcher_Match(t *testing.T) {
+	actual := &errdetails.ErrorInfo{
+		Reason:   "reason",
+		Metadata: map[string]string{"key": "value"},
+	}
+
+	t.Run("should fail when actual is not a proto.Message", func(t *testing.T) {
+		gotMatch, err := (&ProtoGoldenMatcher{Path: "golden.textproto"}).Match("string")
+		assert.ErrorIs(t, err, errProtoEqualActualNotMessage)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail when the golden file does not exist", func(t *testing.T) {
+		gotMatch, err := (&ProtoGoldenMatcher{Path: filepath.Join(t.TempDir(), "golden.textproto")}).Match(actual)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errGoldenFile)
+		assert.Contains(t, err.Error(), UpdateGoldenEnv+"=1")
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail with an invalid golden file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "golden.textproto")
+		require.NoError(t, os.WriteFile(path, []byte("reasn: 'x'"), 0o644))
+		gotMatch, err := (&ProtoGoldenMatcher{Path: path}).Match(actual)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errGoldenFile)
+		assert.Contains(t, err.Error(), "line 1:1")
+		assert.False(t, gotMatch)
+	})
+
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantMatch bool
+	}{
+		{"should match a textproto golden file", "golden.textproto", `reason: "reason" metadata { key: "key" value: "value" }`, true},
+		{"should not match a textproto golden file", "golden.textproto", `reason: "other"`, false},
+		{"should match a JSON golden file", "golden.json", `{"reason": "reason", "metadata": {"key": "value"}}`, true},
+		{"should not match a JSON golden file", "golden.json", `{"reason": "other"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+			gotMatch, err := (&ProtoGoldenMatcher{Path: path}).Match(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMatch, gotMatch)
+		})
+	}
+
+	for _, file := range []string{"golden.textproto", "golden.json"} {
+		t.Run("should update the golden file "+file, func(t *testing.T) {
+			t.Setenv(UpdateGoldenEnv, "1")
+			path := filepath.Join(t.TempDir(), "testdata", file)
+			gotMatch, err := (&ProtoGoldenMatcher{Path: path}).Match(actual)
+			assert.NoError(t, err)
+			assert.True(t, gotMatch)
+
+			t.Setenv(UpdateGoldenEnv, "")
+			gotMatch, err = (&ProtoGoldenMatcher{Path: path}).Match(actual)
+			assert.NoError(t, err)
+			assert.True(t, gotMatch)
+		})
+	}
+
+	t.Run("should write a stable textproto golden file with a header", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "1")
+		path := filepath.Join(t.TempDir(), "golden.textproto")
+		_, err := (&ProtoGoldenMatcher{Path: path}).Match(actual)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# proto-file: google/rpc/error_details.proto
+# proto-message: google.rpc.ErrorInfo
+
+reason: "reason"
+metadata: {
+  key: "key"
+  value: "value"
+}
+`, string(data))
+	})
+
+	t.Run("should keep the spaces of the strings in the textproto golden file", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "1")
+		path := filepath.Join(t.TempDir(), "golden.textproto")
+		spaced := &errdetails.ErrorInfo{Reason: "a  b", Domain: "c:  d"}
+		_, err := (&ProtoGoldenMatcher{Path: path}).Match(spaced)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# proto-file: google/rpc/error_details.proto
+# proto-message: google.rpc.ErrorInfo
+
+reason: "a  b"
+domain: "c:  d"
+`, string(data))
+
+		t.Setenv(UpdateGoldenEnv, "")
+		gotMatch, err := (&ProtoGoldenMatcher{Path: path}).Match(spaced)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+
+	t.Run("should write a stable JSON golden file", func(t *testing.T) {
+		t.Setenv(UpdateGoldenEnv, "1")
+		path := filepath.Join(t.TempDir(), "golden.json")
+		_, err := (&ProtoGoldenMatcher{Path: path}).Match(actual)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "reason": "reason",
+  "metadata": {
+    "key": "value"
+  }
+}
+`, string(data))
+	})
+}
+
+func Test_normalizeTextproto(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"should remove the extra spaces after the field names", "reason:  \"a\"\nmetadata:  {\n  key: \"key\"\n  value:  \"value\"\n}", "reason: \"a\"\nmetadata: {\n  key: \"key\"\n  value: \"value\"\n}"},
+		{"should keep the spaces inside the strings", "reason:  \"a  b\"\ndomain: \"a  b\"", "reason: \"a  b\"\ndomain: \"a  b\""},
+		{"should remove the extra spaces after the extension names", "[pkg.ext]:  1", "[pkg.ext]: 1"},
+		{"should keep the lines that do not start with a field name", "\"a:  b\"\n}", "\"a:  b\"\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(normalizeTextproto([]byte(tt.data))))
+		})
+	}
+}
+
+func TestProtoGoldenMatcher_FailureMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden.textproto")
+	require.NoError(t, os.WriteFile(path, []byte(`reason: "other"`), 0o644))
+	matcher := &ProtoGoldenMatcher{Path: path}
+	actual := &errdetails.ErrorInfo{Reason: "reason"}
+	gotMatch, err := matcher.Match(actual)
+	require.NoError(t, err)
+	require.False(t, gotMatch)
+
+	gotMessage := matcher.FailureMessage(actual)
+	assert.Contains(t, gotMessage, `reason: "other" -> "reason"`)
+	assert.Contains(t, gotMessage, "Golden file: "+path)
+}
+
+func TestProtoGoldenMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&ProtoGoldenMatcher{
+		ProtoEqualMatcher: ProtoEqualMatcher{Expected: &errdetails.ErrorInfo{}},
+		Path:              "golden.textproto",
+	}).NegatedFailureMessage(&errdetails.ErrorInfo{})
+	assert.Contains(t, gotMessage, "not to equal")
+	assert.Contains(t, gotMessage, "Golden file: golden.textproto")
+}
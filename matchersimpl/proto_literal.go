@@ -0,0 +1,86 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errInvalidProtoLiteral = errors.New("invalid proto literal")
+)
+
+// ProtoLiteralFormat is the format of the literal parsed by the ProtoLiteralEqualMatcher.
+type ProtoLiteralFormat int
+
+const (
+	// ProtoLiteralText is the protobuf text format (textproto).
+	ProtoLiteralText ProtoLiteralFormat = iota
+	// ProtoLiteralJSON is the protobuf JSON format.
+	ProtoLiteralJSON
+)
+
+func (f ProtoLiteralFormat) String() string {
+	if f == ProtoLiteralJSON {
+		return "JSON"
+	}
+	return "textproto"
+}
+
+// ProtoLiteralEqualMatcher is a ProtoEqualMatcher whose expected message is written as a textproto or JSON literal.
+// The Literal is parsed into a new message of the same type of Template on the first Match call. Then, the
+// comparison follows the ProtoEqualMatcher rules and options.
+type ProtoLiteralEqualMatcher struct {
+	ProtoEqualMatcher
+	Template proto.Message
+	Literal  string
+	Format   ProtoLiteralFormat
+}
+
+func (matcher *ProtoLiteralEqualMatcher) Match(actual interface{}) (success bool, err error) {
+	if err := matcher.parse(); err != nil {
+		return false, err
+	}
+	return matcher.ProtoEqualMatcher.Match(actual)
+}
+
+// parse fills the ProtoEqualMatcher Expected with the message parsed from the Literal. Parsing errors point to the
+// line and column of the literal where the parsing failed.
+func (matcher *ProtoLiteralEqualMatcher) parse() error {
+	if matcher.Expected != nil {
+		return nil
+	}
+	if matcher.Template == nil {
+		return fmt.Errorf("%w: missing the template message", errInvalidProtoLiteral)
+	}
+	expected := matcher.Template.ProtoReflect().New().Interface()
+	var err error
+	switch matcher.Format {
+	case ProtoLiteralJSON:
+		err = protojson.Unmarshal([]byte(matcher.Literal), expected)
+	default:
+		err = prototext.Unmarshal([]byte(matcher.Literal), expected)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: parsing %s into %s: %v", errInvalidProtoLiteral, matcher.Format, expected.ProtoReflect().Descriptor().FullName(), err)
+	}
+	matcher.Expected = expected
+	return nil
+}
+
+func (matcher *ProtoLiteralEqualMatcher) FailureMessage(actual interface{}) (message string) {
+	if err := matcher.parse(); err != nil {
+		return err.Error()
+	}
+	return matcher.ProtoEqualMatcher.FailureMessage(actual)
+}
+
+func (matcher *ProtoLiteralEqualMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if err := matcher.parse(); err != nil {
+		return err.Error()
+	}
+	return matcher.ProtoEqualMatcher.NegatedFailureMessage(actual)
+}
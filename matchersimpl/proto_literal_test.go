@@ -0,0 +1,93 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestProtoLiteralEqualMatcher_Match(t *testing.T) {
+	actual := &errdetails.ErrorInfo{
+		Reason:   "reason",
+		Metadata: map[string]string{"key": "value"},
+	}
+
+	tests := []struct {
+		name      string
+		format    ProtoLiteralFormat
+		literal   string
+		wantMatch bool
+	}{
+		{"should match a textproto literal", ProtoLiteralText, `reason: "reason" metadata { key: "key" value: "value" }`, true},
+		{"should not match a textproto literal", ProtoLiteralText, `reason: "other"`, false},
+		{"should match a JSON literal", ProtoLiteralJSON, `{"reason": "reason", "metadata": {"key": "value"}}`, true},
+		{"should not match a JSON literal", ProtoLiteralJSON, `{"reason": "reason"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMatch, err := (&ProtoLiteralEqualMatcher{
+				Template: &errdetails.ErrorInfo{},
+				Literal:  tt.literal,
+				Format:   tt.format,
+			}).Match(actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMatch, gotMatch)
+		})
+	}
+
+	t.Run("should fail pointing the line and column of an invalid literal", func(t *testing.T) {
+		gotMatch, err := (&ProtoLiteralEqualMatcher{
+			Template: &errdetails.ErrorInfo{},
+			Literal:  "reason: \"reason\"\nreasn: \"x\"",
+		}).Match(actual)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errInvalidProtoLiteral)
+		assert.Contains(t, err.Error(), "line 2:1")
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should fail without a template", func(t *testing.T) {
+		gotMatch, err := (&ProtoLiteralEqualMatcher{}).Match(actual)
+		assert.ErrorIs(t, err, errInvalidProtoLiteral)
+		assert.False(t, gotMatch)
+	})
+
+	t.Run("should apply the ProtoEqualMatcher options", func(t *testing.T) {
+		gotMatch, err := (&ProtoLiteralEqualMatcher{
+			ProtoEqualMatcher: ProtoEqualMatcher{IgnoredFields: []string{"metadata"}},
+			Template:          &errdetails.ErrorInfo{},
+			Literal:           `reason: "reason"`,
+		}).Match(actual)
+		assert.NoError(t, err)
+		assert.True(t, gotMatch)
+	})
+}
+
+func TestProtoLiteralEqualMatcher_FailureMessage(t *testing.T) {
+	t.Run("should show the differences", func(t *testing.T) {
+		gotMessage := (&ProtoLiteralEqualMatcher{
+			Template: &errdetails.ErrorInfo{},
+			Literal:  `reason: "other"`,
+		}).FailureMessage(&errdetails.ErrorInfo{Reason: "reason"})
+		assert.Contains(t, gotMessage, `reason: "other" -> "reason"`)
+	})
+
+	t.Run("should show the parsing error", func(t *testing.T) {
+		gotMessage := (&ProtoLiteralEqualMatcher{
+			Template: &errdetails.ErrorInfo{},
+			Literal:  `{"reason": }`,
+			Format:   ProtoLiteralJSON,
+		}).FailureMessage(&errdetails.ErrorInfo{Reason: "reason"})
+		assert.Contains(t, gotMessage, "line 1:12")
+	})
+}
+
+func TestProtoLiteralEqualMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&ProtoLiteralEqualMatcher{
+		Template: &errdetails.ErrorInfo{},
+		Literal:  `reason: "reason"`,
+	}).NegatedFailureMessage(&errdetails.ErrorInfo{Reason: "reason"})
+	assert.Contains(t, gotMessage, "not to equal")
+}
@@ -0,0 +1,128 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	errInvalidFieldPath = errors.New("invalid field path")
+)
+
+// fieldPathStep is a single segment of a fieldPath. When wildcard is set, the step goes through every element of a
+// repeated field or every value of a map field.
+type fieldPathStep struct {
+	field    protoreflect.FieldDescriptor
+	wildcard bool
+}
+
+// fieldPath is a field path compiled against a message descriptor (eg: `metadata.create_time` or `items[*].etag`).
+type fieldPath []fieldPathStep
+
+// compileFieldPath parses the given path and resolves each of its segments against the md descriptor. Segments are
+// field names (proto or JSON) separated by dots. Repeated and map fields that are not the last segment must be
+// followed by `[*]`.
+func compileFieldPath(md protoreflect.MessageDescriptor, path string) (fieldPath, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: empty path", errInvalidFieldPath)
+	}
+	segments := strings.Split(path, ".")
+	result := make(fieldPath, 0, len(segments))
+	for i, segment := range segments {
+		if md == nil {
+			return nil, fmt.Errorf("%w %q: %s is not a message", errInvalidFieldPath, path, result)
+		}
+		name := segment
+		wildcard := strings.HasSuffix(segment, "[*]")
+		if wildcard {
+			name = strings.TrimSuffix(segment, "[*]")
+		}
+		fd := findField(md, name)
+		if fd == nil {
+			return nil, fmt.Errorf("%w %q: %s has no field %q", errInvalidFieldPath, path, md.FullName(), name)
+		}
+		if wildcard && !fd.IsList() && !fd.IsMap() {
+			return nil, fmt.Errorf("%w %q: %s is not a repeated or map field", errInvalidFieldPath, path, fd.Name())
+		}
+		if !wildcard && i < len(segments)-1 && (fd.IsList() || fd.IsMap()) {
+			return nil, fmt.Errorf("%w %q: %s is a repeated or map field, use %s[*]", errInvalidFieldPath, path, fd.Name(), fd.Name())
+		}
+		result = append(result, fieldPathStep{field: fd, wildcard: wildcard})
+		md = fieldMessage(fd)
+	}
+	return result, nil
+}
+
+// findField looks for a field by its proto name and, if not found, by its JSON name.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// fieldMessage returns the descriptor of the message held by fd. For map fields, it is the descriptor of the map
+// values. If fd does not hold messages, it returns nil.
+func fieldMessage(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd.IsMap() {
+		return fd.MapValue().Message()
+	}
+	return fd.Message()
+}
+
+func (p fieldPath) String() string {
+	var sb strings.Builder
+	for i, step := range p {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(string(step.field.Name()))
+		if step.wildcard {
+			sb.WriteString("[*]")
+		}
+	}
+	return sb.String()
+}
+
+// key returns the field names of the path separated by dots, the same way the protoComparer identifies the fields it
+// walks through regardless of list indexes and map keys.
+func (p fieldPath) key() string {
+	names := make([]string, len(p))
+	for i, step := range p {
+		names[i] = string(step.field.Name())
+	}
+	return strings.Join(names, ".")
+}
+
+// clear clears the field pointed by the path on m, going through all elements of the wildcard steps.
+func (p fieldPath) clear(m protoreflect.Message) {
+	fd := m.Descriptor().Fields().ByNumber(p[0].field.Number())
+	if fd == nil {
+		return
+	}
+	if len(p) == 1 {
+		m.Clear(fd)
+		return
+	}
+	if !m.Has(fd) {
+		return
+	}
+	rest := p[1:]
+	switch {
+	case fd.IsList():
+		list := m.Mutable(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			rest.clear(list.Get(i).Message())
+		}
+	case fd.IsMap():
+		m.Mutable(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			rest.clear(v.Message())
+			return true
+		})
+	default:
+		rest.clear(m.Mutable(fd).Message())
+	}
+}
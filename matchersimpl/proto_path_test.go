@@ -0,0 +1,92 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_compileFieldPath(t *testing.T) {
+	md := (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantPath string
+		wantErr  string
+	}{
+		{"should compile a single field", "name", "name", ""},
+		{"should compile a nested field", "options.java_package", "options.java_package", ""},
+		{"should compile a wildcard", "message_type[*].field[*].name", "message_type[*].field[*].name", ""},
+		{"should compile a JSON name", "messageType[*].name", "message_type[*].name", ""},
+		{"should fail with an empty path", "", "", "empty path"},
+		{"should fail with an unknown field", "options.java_pkg", "", `google.protobuf.FileOptions has no field "java_pkg"`},
+		{"should fail going through a scalar", "name.length", "", "name is not a message"},
+		{"should fail with a wildcard on a singular field", "options[*].java_package", "", "options is not a repeated or map field"},
+		{"should fail going through a repeated field without wildcard", "message_type.name", "", "use message_type[*]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, err := compileFieldPath(md, tt.path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, errInvalidFieldPath)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, gotPath.String())
+		})
+	}
+}
+
+func TestFieldPath_clear(t *testing.T) {
+	t.Run("should clear a field through repeated messages", func(t *testing.T) {
+		m := &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "field1", Description: "description1"},
+				{Field: "field2", Description: "description2"},
+			},
+		}
+		path, err := compileFieldPath(m.ProtoReflect().Descriptor(), "field_violations[*].description")
+		require.NoError(t, err)
+		path.clear(m.ProtoReflect())
+		assert.True(t, proto.Equal(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "field1"},
+				{Field: "field2"},
+			},
+		}, m))
+	})
+
+	t.Run("should clear a field through map values", func(t *testing.T) {
+		m := &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"key1": structpb.NewStringValue("value1"),
+				"key2": structpb.NewBoolValue(true),
+			},
+		}
+		path, err := compileFieldPath(m.ProtoReflect().Descriptor(), "fields[*].string_value")
+		require.NoError(t, err)
+		path.clear(m.ProtoReflect())
+		assert.True(t, proto.Equal(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"key1": {},
+				"key2": structpb.NewBoolValue(true),
+			},
+		}, m))
+	})
+
+	t.Run("should not populate unset messages", func(t *testing.T) {
+		m := &descriptorpb.FileDescriptorProto{}
+		path, err := compileFieldPath(m.ProtoReflect().Descriptor(), "options.java_package")
+		require.NoError(t, err)
+		path.clear(m.ProtoReflect())
+		assert.Nil(t, m.Options)
+	})
+}
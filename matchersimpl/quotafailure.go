@@ -0,0 +1,44 @@
+package matchersimpl
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func NewGRPCMatchQuotaFailure(matcher types.GomegaMatcher) *GRPCStatusMatcher {
+	return NewGRPCMatchStatusDetail[*errdetails.QuotaFailure](matcher)
+}
+
+// GRPCQuotaViolationMatcher matches an errdetails.QuotaFailure that has at least one violation whose Subject and
+// Description match the given matchers. A nil matcher matches any value.
+type GRPCQuotaViolationMatcher struct {
+	Subject     types.GomegaMatcher
+	Description types.GomegaMatcher
+}
+
+func (m *GRPCQuotaViolationMatcher) props() []DetailProp[*errdetails.QuotaFailure_Violation] {
+	return []DetailProp[*errdetails.QuotaFailure_Violation]{
+		{Name: "subject", PropMap: func(v *errdetails.QuotaFailure_Violation) interface{} { return v.GetSubject() }, Matcher: m.Subject},
+		{Name: "description", PropMap: func(v *errdetails.QuotaFailure_Violation) interface{} { return v.GetDescription() }, Matcher: m.Description},
+	}
+}
+
+func (m *GRPCQuotaViolationMatcher) Match(actual interface{}) (bool, error) {
+	quotaFailure, ok := actual.(*errdetails.QuotaFailure)
+	if !ok {
+		return false, fmt.Errorf("%w: expected an *errdetails.QuotaFailure, got %T", errUnexpectedDetailType, actual)
+	}
+	return matchAnyItem(quotaFailure.GetViolations(), m.props())
+}
+
+func (m *GRPCQuotaViolationMatcher) FailureMessage(actual interface{}) string {
+	quotaFailure, _ := actual.(*errdetails.QuotaFailure)
+	return violationFailureMessage(quotaFailure, "to have a quota violation with", m.props())
+}
+
+func (m *GRPCQuotaViolationMatcher) NegatedFailureMessage(actual interface{}) string {
+	quotaFailure, _ := actual.(*errdetails.QuotaFailure)
+	return violationFailureMessage(quotaFailure, "not to have a quota violation with", m.props())
+}
@@ -0,0 +1,66 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestGRPCQuotaViolationMatcher_Match(t *testing.T) {
+	quotaFailure := &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "subject1", Description: "description1"},
+			{Subject: "subject2", Description: "description2"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		matcher     *GRPCQuotaViolationMatcher
+		wantSuccess bool
+	}{
+		{"should match by subject", &GRPCQuotaViolationMatcher{Subject: gomega.Equal("subject2")}, true},
+		{"should match by subject and description", &GRPCQuotaViolationMatcher{Subject: gomega.Equal("subject1"), Description: gomega.Equal("description1")}, true},
+		{"should not match properties of different violations", &GRPCQuotaViolationMatcher{Subject: gomega.Equal("subject1"), Description: gomega.Equal("description2")}, false},
+		{"should match anything without matchers", &GRPCQuotaViolationMatcher{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(quotaFailure)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+
+	t.Run("should fail when actual is not a QuotaFailure", func(t *testing.T) {
+		gotSuccess, err := (&GRPCQuotaViolationMatcher{}).Match(&errdetails.BadRequest{})
+		assert.ErrorIs(t, err, errUnexpectedDetailType)
+		assert.False(t, gotSuccess)
+	})
+
+	t.Run("should fail when a matcher fails", func(t *testing.T) {
+		gotSuccess, err := (&GRPCQuotaViolationMatcher{Subject: gomega.BeNumerically(">", 1)}).Match(quotaFailure)
+		assert.Error(t, err)
+		assert.False(t, gotSuccess)
+	})
+}
+
+func TestGRPCQuotaViolationMatcher_FailureMessage(t *testing.T) {
+	gotMessage := (&GRPCQuotaViolationMatcher{Subject: gomega.Equal("subject3")}).FailureMessage(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "subject1", Description: "description1"},
+		},
+	})
+	assert.Contains(t, gotMessage, `{"violations":[{"subject":"subject1","description":"description1"}]}`)
+	assert.Contains(t, gotMessage, "to have a quota violation with")
+	assert.Contains(t, gotMessage, "subject: ")
+	assert.Contains(t, gotMessage, "subject3")
+	assert.Contains(t, gotMessage, "description: <any>")
+}
+
+func TestGRPCQuotaViolationMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCQuotaViolationMatcher{Subject: gomega.Equal("subject1")}).NegatedFailureMessage(&errdetails.QuotaFailure{})
+	assert.Contains(t, gotMessage, "not to have a quota violation with")
+}
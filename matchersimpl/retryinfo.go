@@ -0,0 +1,54 @@
+package matchersimpl
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewGRPCMatchRetryInfo(matcher types.GomegaMatcher) *GRPCStatusMatcher {
+	return NewGRPCMatchStatusDetail[*errdetails.RetryInfo](matcher)
+}
+
+// GRPCRetryInfoCodesMatcher implements a StatusMatcher that checks if the given status.Status has an
+// errdetails.RetryInfo in its details and if its code is one of the AllowedCodes. If AllowedCodes is empty, the
+// DefaultRetryableCodes are used.
+type GRPCRetryInfoCodesMatcher struct {
+	AllowedCodes []codes.Code
+}
+
+func (m *GRPCRetryInfoCodesMatcher) allowedCodes() []codes.Code {
+	if len(m.AllowedCodes) == 0 {
+		return DefaultRetryableCodes
+	}
+	return m.AllowedCodes
+}
+
+func (m *GRPCRetryInfoCodesMatcher) allowed(code codes.Code) bool {
+	for _, c := range m.allowedCodes() {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *GRPCRetryInfoCodesMatcher) Match(st *status.Status) (bool, error) {
+	_, ok := findStatusDetail[*errdetails.RetryInfo](st)
+	return ok && m.allowed(st.Code()), nil
+}
+
+func (m *GRPCRetryInfoCodesMatcher) FailureMessage(st *status.Status) string {
+	if _, ok := findStatusDetail[*errdetails.RetryInfo](st); !ok {
+		return detailNotFoundMessage[*errdetails.RetryInfo](st)
+	}
+	return format.Message(st.Code().String(), fmt.Sprintf("to be one of the codes allowed to carry an *errdetails.RetryInfo %v", m.allowedCodes()))
+}
+
+func (m *GRPCRetryInfoCodesMatcher) NegatedFailureMessage(st *status.Status) string {
+	return format.Message(FormatStatus(st), fmt.Sprintf("not to have an *errdetails.RetryInfo with one of the codes %v", m.allowedCodes()))
+}
@@ -0,0 +1,63 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newRetryInfoStatus(t *testing.T, code codes.Code) *status.Status {
+	st, err := status.New(code, "message").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(1),
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestGRPCRetryInfoCodesMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name         string
+		st           *status.Status
+		allowedCodes []codes.Code
+		wantSuccess  bool
+	}{
+		{"should match a default code", newRetryInfoStatus(t, codes.Unavailable), nil, true},
+		{"should not match a code out of the defaults", newRetryInfoStatus(t, codes.Internal), nil, false},
+		{"should match a given code", newRetryInfoStatus(t, codes.Internal), []codes.Code{codes.Internal}, true},
+		{"should not match a code out of the given ones", newRetryInfoStatus(t, codes.Unavailable), []codes.Code{codes.Internal}, false},
+		{"should not match without RetryInfo", status.New(codes.Unavailable, "message"), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := &GRPCRetryInfoCodesMatcher{AllowedCodes: tt.allowedCodes}
+			gotSuccess, err := matcher.Match(tt.st)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+}
+
+func TestGRPCRetryInfoCodesMatcher_FailureMessage(t *testing.T) {
+	t.Run("should fail finding RetryInfo", func(t *testing.T) {
+		gotMessage := (&GRPCRetryInfoCodesMatcher{}).FailureMessage(status.New(codes.Unavailable, "message"))
+		assert.Contains(t, gotMessage, "does not have an *errdetails.RetryInfo in the details")
+	})
+
+	t.Run("should list the allowed codes", func(t *testing.T) {
+		gotMessage := (&GRPCRetryInfoCodesMatcher{}).FailureMessage(newRetryInfoStatus(t, codes.Internal))
+		assert.Contains(t, gotMessage, "Internal")
+		assert.Contains(t, gotMessage, "[Unavailable ResourceExhausted Aborted]")
+	})
+}
+
+func TestGRPCRetryInfoCodesMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCRetryInfoCodesMatcher{}).NegatedFailureMessage(newRetryInfoStatus(t, codes.Unavailable))
+	assert.Contains(t, gotMessage, "not to have an *errdetails.RetryInfo")
+	assert.Contains(t, gotMessage, "type.googleapis.com/google.rpc.RetryInfo: ")
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
@@ -0,0 +1,142 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errExpectedRPCResult = errors.New("the given object is not a RPCResult")
+)
+
+// RPCResult holds the values returned by a unary RPC, so both can be matched in a single assertion.
+type RPCResult struct {
+	Response interface{}
+	Err      error
+}
+
+// hasResponse reports whether the Response is set. Typed nil pointers, such as the (*pb.Book)(nil) returned by the
+// generated clients on failures, are not responses.
+func (r RPCResult) hasResponse() bool {
+	if r.Response == nil {
+		return false
+	}
+	v := reflect.ValueOf(r.Response)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return !v.IsNil()
+	default:
+		return true
+	}
+}
+
+func rpcResultFromActual(actual interface{}) (RPCResult, error) {
+	switch v := actual.(type) {
+	case RPCResult:
+		return v, nil
+	case *RPCResult:
+		if v != nil {
+			return *v, nil
+		}
+	}
+	return RPCResult{}, fmt.Errorf("%w: got %T", errExpectedRPCResult, actual)
+}
+
+// formatRPCResponse renders the response of a RPCResult for the failure messages. Proto responses are rendered as
+// compact JSON (see formatMessage) instead of the internals of their structs.
+func formatRPCResponse(response interface{}) string {
+	if m, ok := response.(proto.Message); ok {
+		return "Response:\n" + format.IndentString(formatMessage(m), 1)
+	}
+	return format.Object(response, 1)
+}
+
+// formatRPCError renders the error of a RPCResult for the failure messages. gRPC errors are rendered as their status
+// (see formatStatus).
+func formatRPCError(err error) string {
+	if st, ok := StatusFrom(err); ok {
+		return formatStatus(st)
+	}
+	return format.Object(err, 1)
+}
+
+// GRPCSucceedWithMatcher matches a RPCResult without error whose Response is matched by the Matcher. A nil Matcher
+// only checks that the RPC succeeded.
+type GRPCSucceedWithMatcher struct {
+	Matcher types.GomegaMatcher
+}
+
+func (m *GRPCSucceedWithMatcher) Match(actual interface{}) (bool, error) {
+	result, err := rpcResultFromActual(actual)
+	if err != nil {
+		return false, err
+	}
+	if result.Err != nil {
+		return false, nil
+	}
+	if m.Matcher == nil {
+		return true, nil
+	}
+	return m.Matcher.Match(result.Response)
+}
+
+func (m *GRPCSucceedWithMatcher) FailureMessage(actual interface{}) string {
+	result, _ := rpcResultFromActual(actual)
+	if result.Err != nil {
+		return "Expected the RPC to succeed, but it failed with\n" + formatRPCError(result.Err)
+	}
+	return m.Matcher.FailureMessage(result.Response)
+}
+
+func (m *GRPCSucceedWithMatcher) NegatedFailureMessage(actual interface{}) string {
+	result, _ := rpcResultFromActual(actual)
+	if m.Matcher == nil {
+		return "Expected the RPC not to succeed, but it returned\n" + formatRPCResponse(result.Response)
+	}
+	return m.Matcher.NegatedFailureMessage(result.Response)
+}
+
+// GRPCFailWithMatcher matches a RPCResult without Response whose Err is matched by the Matcher, usually one of the
+// status matchers. A nil Matcher only checks that the RPC failed.
+type GRPCFailWithMatcher struct {
+	Matcher types.GomegaMatcher
+}
+
+func (m *GRPCFailWithMatcher) Match(actual interface{}) (bool, error) {
+	result, err := rpcResultFromActual(actual)
+	if err != nil {
+		return false, err
+	}
+	if result.Err == nil || result.hasResponse() {
+		return false, nil
+	}
+	if m.Matcher == nil {
+		return true, nil
+	}
+	return m.Matcher.Match(result.Err)
+}
+
+func (m *GRPCFailWithMatcher) FailureMessage(actual interface{}) string {
+	result, _ := rpcResultFromActual(actual)
+	switch {
+	case result.Err == nil:
+		return "Expected the RPC to fail, but it succeeded with\n" + formatRPCResponse(result.Response)
+	case result.hasResponse():
+		return "Expected the RPC to fail without a response, but it returned\n" + formatRPCResponse(result.Response) +
+			"\nwith the error\n" + formatRPCError(result.Err)
+	}
+	return m.Matcher.FailureMessage(result.Err)
+}
+
+func (m *GRPCFailWithMatcher) NegatedFailureMessage(actual interface{}) string {
+	result, _ := rpcResultFromActual(actual)
+	if m.Matcher == nil {
+		return "Expected the RPC not to fail, but it failed with\n" + formatRPCError(result.Err)
+	}
+	return m.Matcher.NegatedFailureMessage(result.Err)
+}
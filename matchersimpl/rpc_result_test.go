@@ -0,0 +1,170 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPCResult_hasResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response interface{}
+		want     bool
+	}{
+		{"should not have a nil response", nil, false},
+		{"should not have a typed nil response", (*errdetails.ErrorInfo)(nil), false},
+		{"should have a message response", &errdetails.ErrorInfo{}, true},
+		{"should have a value response", "value", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RPCResult{Response: tt.response}.hasResponse())
+		})
+	}
+}
+
+func TestGRPCSucceedWithMatcher_Match(t *testing.T) {
+	response := &errdetails.ErrorInfo{Reason: "reason"}
+
+	t.Run("should match the response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		gm.EXPECT().Match(response).Return(true, nil)
+
+		gotSuccess, err := (&GRPCSucceedWithMatcher{Matcher: gm}).Match(RPCResult{Response: response})
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should match any response without a matcher", func(t *testing.T) {
+		gotSuccess, err := (&GRPCSucceedWithMatcher{}).Match(&RPCResult{Response: response})
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should not match a failed RPC", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		result := RPCResult{Err: status.Error(codes.NotFound, "not found")}
+
+		matcher := &GRPCSucceedWithMatcher{Matcher: gm}
+		gotSuccess, err := matcher.Match(result)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		assert.Contains(t, matcher.FailureMessage(result), "Expected the RPC to succeed, but it failed with")
+	})
+
+	t.Run("should fail when the actual is not a RPCResult", func(t *testing.T) {
+		_, err := (&GRPCSucceedWithMatcher{}).Match(response)
+		assert.ErrorIs(t, err, errExpectedRPCResult)
+	})
+}
+
+func TestGRPCSucceedWithMatcher_FailureMessage(t *testing.T) {
+	response := &errdetails.ErrorInfo{Reason: "reason"}
+	wantMessage := "failure message"
+
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	gm.EXPECT().FailureMessage(response).Return(wantMessage)
+	gm.EXPECT().NegatedFailureMessage(response).Return("negated " + wantMessage)
+
+	matcher := &GRPCSucceedWithMatcher{Matcher: gm}
+	assert.Equal(t, wantMessage, matcher.FailureMessage(RPCResult{Response: response}))
+	assert.Equal(t, "negated "+wantMessage, matcher.NegatedFailureMessage(RPCResult{Response: response}))
+}
+
+func TestGRPCSucceedWithMatcher_FailureMessage_failed(t *testing.T) {
+	result := RPCResult{Err: status.Error(codes.NotFound, "not found")}
+
+	gotMessage := (&GRPCSucceedWithMatcher{}).FailureMessage(result)
+	assert.Contains(t, gotMessage, "Status: NotFound,")
+	assert.Contains(t, gotMessage, "Message: not found,")
+	assert.NotContains(t, gotMessage, "codes.Code")
+
+	gotMessage = (&GRPCSucceedWithMatcher{}).NegatedFailureMessage(RPCResult{Response: &errdetails.ErrorInfo{Reason: "reason"}})
+	assert.Contains(t, gotMessage, `{"reason":"reason"}`)
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
+
+func TestGRPCFailWithMatcher_Match(t *testing.T) {
+	rpcErr := status.Error(codes.NotFound, "not found")
+
+	t.Run("should match the error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		gm.EXPECT().Match(rpcErr).Return(true, nil)
+
+		gotSuccess, err := (&GRPCFailWithMatcher{Matcher: gm}).Match(RPCResult{Response: (*errdetails.ErrorInfo)(nil), Err: rpcErr})
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should match any error without a matcher", func(t *testing.T) {
+		gotSuccess, err := (&GRPCFailWithMatcher{}).Match(RPCResult{Err: rpcErr})
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should not match a successful RPC", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		result := RPCResult{Response: &errdetails.ErrorInfo{}}
+
+		matcher := &GRPCFailWithMatcher{Matcher: gm}
+		gotSuccess, err := matcher.Match(result)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		assert.Contains(t, matcher.FailureMessage(result), "Expected the RPC to fail, but it succeeded with")
+	})
+
+	t.Run("should not match a failed RPC with a response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		result := RPCResult{Response: &errdetails.ErrorInfo{}, Err: rpcErr}
+
+		matcher := &GRPCFailWithMatcher{Matcher: gm}
+		gotSuccess, err := matcher.Match(result)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		assert.Contains(t, matcher.FailureMessage(result), "Expected the RPC to fail without a response, but it returned")
+	})
+
+	t.Run("should fail when the actual is not a RPCResult", func(t *testing.T) {
+		_, err := (&GRPCFailWithMatcher{}).Match(rpcErr)
+		assert.ErrorIs(t, err, errExpectedRPCResult)
+	})
+}
+
+func TestGRPCFailWithMatcher_FailureMessage(t *testing.T) {
+	rpcErr := status.Error(codes.NotFound, "not found")
+	wantMessage := "failure message"
+
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	gm.EXPECT().FailureMessage(rpcErr).Return(wantMessage)
+	gm.EXPECT().NegatedFailureMessage(rpcErr).Return("negated " + wantMessage)
+
+	matcher := &GRPCFailWithMatcher{Matcher: gm}
+	assert.Equal(t, wantMessage, matcher.FailureMessage(RPCResult{Err: rpcErr}))
+	assert.Equal(t, "negated "+wantMessage, matcher.NegatedFailureMessage(RPCResult{Err: rpcErr}))
+}
+
+func TestGRPCFailWithMatcher_FailureMessage_succeeded(t *testing.T) {
+	response := &errdetails.ErrorInfo{Reason: "reason"}
+
+	gotMessage := (&GRPCFailWithMatcher{}).FailureMessage(RPCResult{Response: response})
+	assert.Contains(t, gotMessage, `{"reason":"reason"}`)
+	assert.NotContains(t, gotMessage, "sizeCache")
+
+	gotMessage = (&GRPCFailWithMatcher{}).FailureMessage(RPCResult{Response: response, Err: status.Error(codes.NotFound, "not found")})
+	assert.Contains(t, gotMessage, `{"reason":"reason"}`)
+	assert.Contains(t, gotMessage, "Status: NotFound,")
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
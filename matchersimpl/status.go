@@ -2,17 +2,67 @@ package matchersimpl
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
-	errExpectedError     = errors.New("the given object is not an error")
+	errExpectedError     = errors.New("the given object is not an error, a *status.Status or a *spb.Status")
 	errStatusErrExpected = errors.New("the given error is not a GRPC error")
 )
 
+// grpcStatusError is implemented by the errors created by the status package.
+type grpcStatusError interface {
+	GRPCStatus() *status.Status
+}
+
+// statusFromActual converts the actual values accepted by the matchers to a *status.Status. The supported values are:
+//   - errors created by the status package, or errors wrapping them with %w;
+//   - *status.Status;
+//   - *spb.Status, the google.rpc.Status proto.
+//
+// All matchers rely on it, so they support the same values.
+func statusFromActual(actual interface{}) (*status.Status, error) {
+	switch v := actual.(type) {
+	case *status.Status:
+		return v, nil
+	case *spb.Status:
+		return status.FromProto(v), nil
+	case error:
+		var grpcErr grpcStatusError
+		if !errors.As(v, &grpcErr) {
+			return nil, fmt.Errorf("%w: got %T: %s", errStatusErrExpected, actual, v)
+		}
+		return grpcErr.GRPCStatus(), nil
+	default:
+		return nil, fmt.Errorf("%w: got %T", errExpectedError, actual)
+	}
+}
+
+// StatusFrom converts the values accepted by the status matchers (see statusFromActual) to a *status.Status. It
+// returns false for any other value.
+func StatusFrom(actual interface{}) (*status.Status, bool) {
+	st, err := statusFromActual(actual)
+	return st, err == nil
+}
+
+// actualShapeMessage builds the failure message for the actual values rejected by statusFromActual.
+func actualShapeMessage(actual interface{}, err error) string {
+	if errors.Is(err, errStatusErrExpected) {
+		return format.Message(actual, "is not a grpc error and does not wrap one")
+	}
+	return format.Message(actual, "is not an error, a *status.Status or a *spb.Status")
+}
+
 // StatusMatcher abstracts a matcher specialized on status.Status types.
 type StatusMatcher interface {
 	Match(st *status.Status) (bool, error)
@@ -34,27 +84,20 @@ func NewGRPCStatusMatcher(statusMatcher StatusMatcher) *GRPCStatusMatcher {
 	return &GRPCStatusMatcher{statusMatcher}
 }
 
-// Match validates if the given actual is a status.Status, if so it will call the matchFunc.
+// Match converts the given actual to a status.Status (see statusFromActual), if it succeeds it will call the
+// StatusMatcher.
 func (matcher *GRPCStatusMatcher) Match(actual interface{}) (success bool, err error) {
-	actualErr, ok := actual.(error)
-	if !ok {
-		return false, errExpectedError
-	}
-	st, ok := status.FromError(actualErr)
-	if !ok {
-		return false, errStatusErrExpected
+	st, err := statusFromActual(actual)
+	if err != nil {
+		return false, err
 	}
 	return matcher.statusMatcher.Match(st)
 }
 
 func (matcher *GRPCStatusMatcher) validateActual(actual interface{}) (string, *status.Status, bool) {
-	actualErr, ok := actual.(error)
-	if !ok {
-		return format.Message(actual, "is not an error"), nil, false
-	}
-	st, ok := status.FromError(actualErr)
-	if !ok {
-		return format.Message(actual, "is not a grpc error"), nil, false
+	st, err := statusFromActual(actual)
+	if err != nil {
+		return actualShapeMessage(actual, err), nil, false
 	}
 	return "", st, true
 }
@@ -75,6 +118,9 @@ func (matcher *GRPCStatusMatcher) NegatedFailureMessage(actual interface{}) (mes
 	return matcher.statusMatcher.NegatedFailureMessage(st)
 }
 
+// GRPCStatusPropMatcher implements a StatusMatcher that applies the Matcher to the property extracted by PropMap
+// (eg: the code or the message). Failure messages render the codes and details with readableValue and are followed by
+// the full status.
 type GRPCStatusPropMatcher struct {
 	PropMap func(status *status.Status) interface{}
 	Matcher gomega.OmegaMatcher
@@ -85,9 +131,68 @@ func (matcher *GRPCStatusPropMatcher) Match(st *status.Status) (bool, error) {
 }
 
 func (matcher *GRPCStatusPropMatcher) FailureMessage(st *status.Status) string {
-	return matcher.Matcher.FailureMessage(matcher.PropMap(st))
+	return propFailureMessage(matcher.Matcher, matcher.PropMap(st), false) + "\n" + formatStatus(st)
 }
 
 func (matcher *GRPCStatusPropMatcher) NegatedFailureMessage(st *status.Status) string {
-	return matcher.Matcher.NegatedFailureMessage(matcher.PropMap(st))
+	return propFailureMessage(matcher.Matcher, matcher.PropMap(st), true) + "\n" + formatStatus(st)
+}
+
+// formatStatus renders the full status (code, message and details, see FormatStatus) to follow the failure messages.
+func formatStatus(st *status.Status) string {
+	return "Status:\n" + format.IndentString(FormatStatus(st), 1)
+}
+
+// statusCode is a codes.Code rendered with its name and number (see formatCode) in the failure messages.
+type statusCode codes.Code
+
+func (c statusCode) GomegaString() string {
+	return formatCode(codes.Code(c))
+}
+
+// statusDetails are the decoded status details rendered as describeStatusDetails does in the failure messages, instead
+// of the internals of the proto structs.
+type statusDetails []proto.Message
+
+func (d statusDetails) GomegaString() string {
+	details := make([]*anypb.Any, 0, len(d))
+	for _, detail := range d {
+		anyDetail, err := anypb.New(detail)
+		if err != nil {
+			return format.Object([]proto.Message(d), 0)
+		}
+		details = append(details, anyDetail)
+	}
+	return describeDetails(details)
+}
+
+// readableValue wraps the status properties whose Go values do not read well in the failure messages (the codes and
+// the decoded details). Other values are returned as they are.
+func readableValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case codes.Code:
+		return statusCode(v)
+	case []proto.Message:
+		return statusDetails(v)
+	default:
+		return value
+	}
+}
+
+// propFailureMessage returns the (negated) failure message of matcher for the status property value, rendering it with
+// readableValue. The value is only wrapped for the message, the matcher still matches the original one. Equal
+// matchers on codes also render the expected code, so both sides read like NotFound (5).
+func propFailureMessage(matcher types.GomegaMatcher, value interface{}, negated bool) string {
+	if equal, ok := matcher.(*matchers.EqualMatcher); ok {
+		if expected, ok := equal.Expected.(codes.Code); ok {
+			if negated {
+				return format.Message(readableValue(value), "not to equal", statusCode(expected))
+			}
+			return format.Message(readableValue(value), "to equal", statusCode(expected))
+		}
+	}
+	if negated {
+		return matcher.NegatedFailureMessage(readableValue(value))
+	}
+	return matcher.FailureMessage(readableValue(value))
 }
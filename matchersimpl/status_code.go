@@ -0,0 +1,98 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errInvalidCodeName = errors.New("invalid status code name")
+)
+
+// DefaultRetryableCodes are the status codes considered retryable when no codes are given, following the gRPC status
+// codes guidance: transient failures (Unavailable), exhausted quotas (ResourceExhausted) and concurrency conflicts
+// (Aborted).
+var DefaultRetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted}
+
+// GRPCStatusCodeInMatcher implements a StatusMatcher that succeeds if the status code is one of the Codes or one of
+// the codes named by Names.
+type GRPCStatusCodeInMatcher struct {
+	Codes []codes.Code
+	// Names are code names, in the proto (NOT_FOUND) or Go (NotFound) spelling.
+	Names []string
+	// Description names the set of codes on the failure messages (eg: "a retryable code"). When empty, the codes are
+	// listed instead.
+	Description string
+}
+
+func (m *GRPCStatusCodeInMatcher) codes() ([]codes.Code, error) {
+	result := append([]codes.Code{}, m.Codes...)
+	for _, name := range m.Names {
+		code, err := ParseCode(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, code)
+	}
+	return result, nil
+}
+
+func (m *GRPCStatusCodeInMatcher) Match(st *status.Status) (bool, error) {
+	expected, err := m.codes()
+	if err != nil {
+		return false, err
+	}
+	for _, code := range expected {
+		if st.Code() == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *GRPCStatusCodeInMatcher) describe() string {
+	if m.Description != "" {
+		return m.Description
+	}
+	expected, err := m.codes()
+	if err != nil {
+		return err.Error()
+	}
+	names := make([]string, len(expected))
+	for i, code := range expected {
+		names[i] = formatCode(code)
+	}
+	return "one of " + strings.Join(names, ", ")
+}
+
+func (m *GRPCStatusCodeInMatcher) FailureMessage(st *status.Status) string {
+	return format.Message(formatCode(st.Code()), "to be "+m.describe()) + "\n" + formatStatus(st)
+}
+
+func (m *GRPCStatusCodeInMatcher) NegatedFailureMessage(st *status.Status) string {
+	return format.Message(formatCode(st.Code()), "not to be "+m.describe()) + "\n" + formatStatus(st)
+}
+
+// ParseCode parses a status code name, either in its proto spelling (NOT_FOUND) or its Go spelling (NotFound).
+func ParseCode(name string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(fmt.Sprintf("%q", strings.ToUpper(name)))); err == nil {
+		return code, nil
+	}
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), name) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errInvalidCodeName, name)
+}
+
+// formatCode renders a code with its name and number (eg: NotFound (5)).
+func formatCode(code codes.Code) string {
+	return fmt.Sprintf("%s (%d)", code.String(), code)
+}
@@ -0,0 +1,77 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		given    string
+		wantCode codes.Code
+		wantErr  error
+	}{
+		{"NOT_FOUND", codes.NotFound, nil},
+		{"not_found", codes.NotFound, nil},
+		{"NotFound", codes.NotFound, nil},
+		{"OK", codes.OK, nil},
+		{"DEADLINE_EXCEEDED", codes.DeadlineExceeded, nil},
+		{"Canceled", codes.Canceled, nil},
+		{"NOT_FUOND", 0, errInvalidCodeName},
+		{"5", 0, errInvalidCodeName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			gotCode, err := ParseCode(tt.given)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCode, gotCode)
+		})
+	}
+}
+
+func TestGRPCStatusCodeInMatcher_Match(t *testing.T) {
+	st := status.New(codes.NotFound, "not found")
+
+	tests := []struct {
+		name        string
+		matcher     *GRPCStatusCodeInMatcher
+		wantSuccess bool
+		wantErr     error
+	}{
+		{"should match one of the codes", &GRPCStatusCodeInMatcher{Codes: []codes.Code{codes.Internal, codes.NotFound}}, true, nil},
+		{"should match one of the names", &GRPCStatusCodeInMatcher{Names: []string{"INTERNAL", "NOT_FOUND"}}, true, nil},
+		{"should not match other codes", &GRPCStatusCodeInMatcher{Codes: []codes.Code{codes.Internal}, Names: []string{"UNAVAILABLE"}}, false, nil},
+		{"should not match without codes", &GRPCStatusCodeInMatcher{}, false, nil},
+		{"should fail with an invalid name", &GRPCStatusCodeInMatcher{Names: []string{"NOT_FUOND"}}, false, errInvalidCodeName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(st)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+}
+
+func TestGRPCStatusCodeInMatcher_FailureMessage(t *testing.T) {
+	st := status.New(codes.NotFound, "not found")
+
+	t.Run("should list the codes", func(t *testing.T) {
+		gotMessage := (&GRPCStatusCodeInMatcher{Codes: []codes.Code{codes.Internal}, Names: []string{"UNAVAILABLE"}}).FailureMessage(st)
+		assert.Contains(t, gotMessage, "NotFound (5)")
+		assert.Contains(t, gotMessage, "to be one of Internal (13), Unavailable (14)")
+	})
+
+	t.Run("should use the description", func(t *testing.T) {
+		gotMessage := (&GRPCStatusCodeInMatcher{Codes: []codes.Code{codes.Internal}, Description: "a server error"}).FailureMessage(st)
+		assert.Contains(t, gotMessage, "to be a server error")
+	})
+}
+
+func TestGRPCStatusCodeInMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCStatusCodeInMatcher{Codes: []codes.Code{codes.NotFound}}).NegatedFailureMessage(status.New(codes.NotFound, "not found"))
+	assert.Contains(t, gotMessage, "not to be one of NotFound (5)")
+}
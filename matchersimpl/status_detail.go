@@ -0,0 +1,171 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	errStatusDetailNotFound = errors.New("detail not found")
+	errUnexpectedDetailType = errors.New("unexpected detail type")
+)
+
+func NewGRPCMatchStatusDetail[T proto.Message](matcher types.GomegaMatcher) *GRPCStatusMatcher {
+	return NewGRPCStatusMatcher(&GRPCStatusDetailMatcher[T]{Matcher: matcher})
+}
+
+// GRPCStatusDetailMatcher implements a StatusMatcher that finds the first detail of type T on the given status.Status
+// and applies the Matcher to it. Failure messages are followed by the detail.
+type GRPCStatusDetailMatcher[T proto.Message] struct {
+	Matcher types.GomegaMatcher
+}
+
+func (m *GRPCStatusDetailMatcher[T]) Match(st *status.Status) (bool, error) {
+	detail, ok := findStatusDetail[T](st)
+	if !ok {
+		return false, fmt.Errorf("%w: no %s found in the details", errStatusDetailNotFound, detailTypeName[T]())
+	}
+	return m.Matcher.Match(detail)
+}
+
+func (m *GRPCStatusDetailMatcher[T]) FailureMessage(st *status.Status) string {
+	detail, ok := findStatusDetail[T](st)
+	if !ok {
+		return detailNotFoundMessage[T](st)
+	}
+	return m.Matcher.FailureMessage(detail) + "\n" + formatDetail(detail)
+}
+
+func (m *GRPCStatusDetailMatcher[T]) NegatedFailureMessage(st *status.Status) string {
+	detail, ok := findStatusDetail[T](st)
+	if !ok {
+		return detailNotFoundMessage[T](st)
+	}
+	return m.Matcher.NegatedFailureMessage(detail) + "\n" + formatDetail(detail)
+}
+
+// formatDetail renders the detail matched by the GRPCStatusDetailMatcher (see formatMessage) to follow the failure
+// messages of its Matcher, which receive the detail struct.
+func formatDetail(detail proto.Message) string {
+	return "Detail:\n" + format.IndentString(formatMessage(detail), 1)
+}
+
+// detailNotFoundMessage builds the failure message for when there is no detail of type T on the st, listing the
+// details that are actually there.
+func detailNotFoundMessage[T proto.Message](st *status.Status) string {
+	return format.Message(describeStatusDetails(st), fmt.Sprintf("does not have an %s in the details", detailTypeName[T]()))
+}
+
+// findStatusDetail walks through the error details of the given st trying to find an instance of T. If it find any,
+// returns the instance and true.
+//
+// Details are decoded straight from their google.protobuf.Any representation, so any message type can be found,
+// including the ones defined by the users.
+//
+// Otherwise, it returns false.
+func findStatusDetail[T proto.Message](st *status.Status) (T, bool) {
+	var zero T
+	details := findStatusDetails[T](st)
+	if len(details) == 0 {
+		return zero, false
+	}
+	return details[0], true
+}
+
+// findStatusDetails works as findStatusDetail, but returns all the details of type T, in the order they appear.
+//
+// When T is an interface (eg: proto.Message), every detail that can be decoded and implements T is returned.
+func findStatusDetails[T proto.Message](st *status.Status) []T {
+	var (
+		zero    T
+		details []T
+	)
+	if isInterface := any(zero) == nil; isInterface {
+		for _, anyDetail := range st.Proto().GetDetails() {
+			decoded, err := anyDetail.UnmarshalNew()
+			if err != nil {
+				continue
+			}
+			if detail, ok := decoded.(T); ok {
+				details = append(details, detail)
+			}
+		}
+		return details
+	}
+	for _, anyDetail := range st.Proto().GetDetails() {
+		if !anyDetail.MessageIs(zero) {
+			continue
+		}
+		detail, ok := zero.ProtoReflect().New().Interface().(T)
+		if !ok {
+			continue
+		}
+		if err := anyDetail.UnmarshalTo(detail); err != nil {
+			continue
+		}
+		details = append(details, detail)
+	}
+	return details
+}
+
+// describeStatusDetails renders the details of st, one per line, with their type URLs, using the StatusDetailsFormat
+// (see FormatStatusDetail).
+func describeStatusDetails(st *status.Status) string {
+	return describeDetails(st.Proto().GetDetails())
+}
+
+// describeDetails renders the details, one per line, as describeStatusDetails does.
+func describeDetails(details []*anypb.Any) string {
+	if len(details) == 0 {
+		return "<no details>"
+	}
+	lines := make([]string, len(details))
+	for i, anyDetail := range details {
+		lines[i] = FormatStatusDetail(anyDetail, StatusDetailsFormat())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// detailTypeName returns the Go type name of T (eg: *errdetails.ErrorInfo).
+func detailTypeName[T proto.Message]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// GRPCStatusDetailPropMatcher implements a gomega.Matcher for details of type T, applying the Matcher to the property
+// extracted by PropMap.
+type GRPCStatusDetailPropMatcher[T proto.Message] struct {
+	PropMap func(detail T) interface{}
+	Matcher types.GomegaMatcher
+}
+
+func (m *GRPCStatusDetailPropMatcher[T]) Match(actual interface{}) (bool, error) {
+	detail, ok := actual.(T)
+	if !ok {
+		return false, fmt.Errorf("%w: expected an %s, got %T", errUnexpectedDetailType, detailTypeName[T](), actual)
+	}
+	return m.Matcher.Match(m.PropMap(detail))
+}
+
+func (m *GRPCStatusDetailPropMatcher[T]) FailureMessage(actual interface{}) string {
+	detail, ok := actual.(T)
+	if !ok {
+		return format.Message(actual, "is not an "+detailTypeName[T]())
+	}
+	return m.Matcher.FailureMessage(m.PropMap(detail))
+}
+
+func (m *GRPCStatusDetailPropMatcher[T]) NegatedFailureMessage(actual interface{}) string {
+	detail, ok := actual.(T)
+	if !ok {
+		return format.Message(actual, "is not an "+detailTypeName[T]())
+	}
+	return m.Matcher.NegatedFailureMessage(m.PropMap(detail))
+}
@@ -0,0 +1,45 @@
+package matchersimpl
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// GRPCStatusDetailItemsMatcher implements a StatusMatcher that collects items of type I from all the details of type
+// T on the given status.Status (eg: the links of all errdetails.Help) and succeeds if any of them has all its Props
+// matched.
+//
+// The failure messages list all the details present on the status, so it is clear what was returned instead.
+type GRPCStatusDetailItemsMatcher[T proto.Message, I any] struct {
+	// Description names the expected item on the failure messages (eg: "a help link").
+	Description string
+	// Items extracts the items from a detail.
+	Items func(detail T) []I
+	Props []DetailProp[I]
+}
+
+func (m *GRPCStatusDetailItemsMatcher[T, I]) Match(st *status.Status) (bool, error) {
+	details := findStatusDetails[T](st)
+	if len(details) == 0 {
+		return false, fmt.Errorf("%w: no %s found in the details", errStatusDetailNotFound, detailTypeName[T]())
+	}
+	var items []I
+	for _, detail := range details {
+		items = append(items, m.Items(detail)...)
+	}
+	return matchAnyItem(items, m.Props)
+}
+
+func (m *GRPCStatusDetailItemsMatcher[T, I]) FailureMessage(st *status.Status) string {
+	if _, ok := findStatusDetail[T](st); !ok {
+		return detailNotFoundMessage[T](st)
+	}
+	return format.Message(describeStatusDetails(st), "to have "+m.Description+" with") + "\n" + describeDetailProps(m.Props)
+}
+
+func (m *GRPCStatusDetailItemsMatcher[T, I]) NegatedFailureMessage(st *status.Status) string {
+	return format.Message(describeStatusDetails(st), "not to have "+m.Description+" with") + "\n" + describeDetailProps(m.Props)
+}
@@ -0,0 +1,92 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newHelpLinkMatcher(description, url string) *GRPCStatusDetailItemsMatcher[*errdetails.Help, *errdetails.Help_Link] {
+	return &GRPCStatusDetailItemsMatcher[*errdetails.Help, *errdetails.Help_Link]{
+		Description: "a help link",
+		Items: func(help *errdetails.Help) []*errdetails.Help_Link {
+			return help.GetLinks()
+		},
+		Props: []DetailProp[*errdetails.Help_Link]{
+			{Name: "description", PropMap: func(l *errdetails.Help_Link) interface{} { return l.GetDescription() }, Matcher: gomega.Equal(description)},
+			{Name: "url", PropMap: func(l *errdetails.Help_Link) interface{} { return l.GetUrl() }, Matcher: gomega.Equal(url)},
+		},
+	}
+}
+
+func TestGRPCStatusDetailItemsMatcher_Match(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(
+		&errdetails.Help{Links: []*errdetails.Help_Link{{Description: "docs", Url: "https://example.com/docs"}}},
+		&errdetails.Help{Links: []*errdetails.Help_Link{{Description: "quota", Url: "https://example.com/quota"}}},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		matcher     *GRPCStatusDetailItemsMatcher[*errdetails.Help, *errdetails.Help_Link]
+		wantSuccess bool
+	}{
+		{"should match an item of the first detail", newHelpLinkMatcher("docs", "https://example.com/docs"), true},
+		{"should match an item of the other details", newHelpLinkMatcher("quota", "https://example.com/quota"), true},
+		{"should not match properties of different items", newHelpLinkMatcher("docs", "https://example.com/quota"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSuccess, err := tt.matcher.Match(st)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+		})
+	}
+
+	t.Run("should fail when there is no detail", func(t *testing.T) {
+		gotSuccess, err := newHelpLinkMatcher("docs", "").Match(status.New(codes.Internal, "message"))
+		assert.ErrorIs(t, err, errStatusDetailNotFound)
+		assert.False(t, gotSuccess)
+	})
+}
+
+func TestGRPCStatusDetailItemsMatcher_FailureMessage(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(
+		&errdetails.RequestInfo{RequestId: "rid"},
+		&errdetails.Help{Links: []*errdetails.Help_Link{{Description: "docs", Url: "https://example.com/docs"}}},
+	)
+	require.NoError(t, err)
+
+	t.Run("should list the details present", func(t *testing.T) {
+		gotMessage := newHelpLinkMatcher("quota", "https://example.com/quota").FailureMessage(st)
+		assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.RequestInfo: {"requestId":"rid"}`)
+		assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.Help: {"links":[{"description":"docs","url":"https://example.com/docs"}]}`)
+		assert.Contains(t, gotMessage, "to have a help link with")
+		assert.Contains(t, gotMessage, "description: ")
+		assert.Contains(t, gotMessage, "https://example.com/quota")
+	})
+
+	t.Run("should list the details when the detail is missing", func(t *testing.T) {
+		withoutHelp, err := status.New(codes.ResourceExhausted, "message").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+		require.NoError(t, err)
+		gotMessage := newHelpLinkMatcher("quota", "https://example.com/quota").FailureMessage(withoutHelp)
+		assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.RequestInfo: {"requestId":"rid"}`)
+		assert.Contains(t, gotMessage, "does not have an *errdetails.Help in the details")
+	})
+}
+
+func TestGRPCStatusDetailItemsMatcher_NegatedFailureMessage(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(
+		&errdetails.Help{Links: []*errdetails.Help_Link{{Description: "docs", Url: "https://example.com/docs"}}},
+	)
+	require.NoError(t, err)
+
+	gotMessage := newHelpLinkMatcher("docs", "https://example.com/docs").NegatedFailureMessage(st)
+	assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.Help: {"links":[{"description":"docs","url":"https://example.com/docs"}]}`)
+	assert.Contains(t, gotMessage, "not to have a help link with")
+}
@@ -0,0 +1,220 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jamillosantos/gomock-grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_findStatusDetail(t *testing.T) {
+	st, err := status.New(codes.Internal, "message").WithDetails(
+		&errdetails.RequestInfo{RequestId: "rid"},
+		wrapperspb.String("custom detail"),
+	)
+	require.NoError(t, err)
+
+	t.Run("should find a detail", func(t *testing.T) {
+		gotDetail, ok := findStatusDetail[*errdetails.RequestInfo](st)
+		assert.True(t, ok)
+		assert.True(t, proto.Equal(&errdetails.RequestInfo{RequestId: "rid"}, gotDetail))
+	})
+
+	t.Run("should find a custom detail", func(t *testing.T) {
+		gotDetail, ok := findStatusDetail[*wrapperspb.StringValue](st)
+		assert.True(t, ok)
+		assert.Equal(t, "custom detail", gotDetail.GetValue())
+	})
+
+	t.Run("should not find a detail", func(t *testing.T) {
+		gotDetail, ok := findStatusDetail[*errdetails.ErrorInfo](st)
+		assert.False(t, ok)
+		assert.Nil(t, gotDetail)
+	})
+}
+
+func TestGRPCStatusDetailMatcher_Match(t *testing.T) {
+	wantDetail := &errdetails.RequestInfo{RequestId: "rid"}
+	st, err := status.New(codes.Internal, "message").WithDetails(wantDetail)
+	require.NoError(t, err)
+
+	t.Run("should fail when there is no detail", func(t *testing.T) {
+		matcher := NewGRPCMatchStatusDetail[*errdetails.ErrorInfo](nil)
+		gotResult, err := matcher.Match(st.Err())
+		assert.False(t, gotResult)
+		assert.ErrorIs(t, err, errStatusDetailNotFound)
+	})
+
+	t.Run("should return the match result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		matcher := NewGRPCMatchStatusDetail[*errdetails.RequestInfo](gm)
+
+		gm.EXPECT().Match(gomockgrpc.ProtoEqual(wantDetail)).Return(true, nil)
+
+		gotResult, err := matcher.Match(st.Err())
+		assert.True(t, gotResult)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGRPCStatusDetailMatcher_FailureMessage(t *testing.T) {
+	t.Run("should fail finding the detail", func(t *testing.T) {
+		matcher := &GRPCStatusDetailMatcher[*errdetails.ErrorInfo]{}
+		gotMessage := matcher.FailureMessage(status.New(codes.Internal, "random error"))
+		assert.Contains(t, gotMessage, "does not have an *errdetails.ErrorInfo in the details")
+	})
+
+	t.Run("should call the matcher FailureMessage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		matcher := &GRPCStatusDetailMatcher[*errdetails.RequestInfo]{Matcher: gm}
+
+		wantDetail := &errdetails.RequestInfo{RequestId: "rid"}
+		st, err := status.New(codes.Internal, "random error").WithDetails(wantDetail)
+		require.NoError(t, err)
+
+		gm.EXPECT().FailureMessage(gomockgrpc.ProtoEqual(wantDetail)).Return(wantMessage)
+
+		gotMessage := matcher.FailureMessage(st)
+		assert.Equal(t, wantMessage+"\nDetail:\n    {\"requestId\":\"rid\"}", gotMessage)
+	})
+}
+
+func TestGRPCStatusDetailMatcher_NegatedFailureMessage(t *testing.T) {
+	t.Run("should fail finding the detail", func(t *testing.T) {
+		matcher := &GRPCStatusDetailMatcher[*errdetails.ErrorInfo]{}
+		gotMessage := matcher.NegatedFailureMessage(status.New(codes.Internal, "random error"))
+		assert.Contains(t, gotMessage, "does not have an *errdetails.ErrorInfo in the details")
+	})
+
+	t.Run("should call the matcher NegatedFailureMessage", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		matcher := &GRPCStatusDetailMatcher[*errdetails.RequestInfo]{Matcher: gm}
+
+		wantDetail := &errdetails.RequestInfo{RequestId: "rid"}
+		st, err := status.New(codes.Internal, "random error").WithDetails(wantDetail)
+		require.NoError(t, err)
+
+		gm.EXPECT().NegatedFailureMessage(gomockgrpc.ProtoEqual(wantDetail)).Return(wantMessage)
+
+		gotMessage := matcher.NegatedFailureMessage(st)
+		assert.Equal(t, wantMessage+"\nDetail:\n    {\"requestId\":\"rid\"}", gotMessage)
+	})
+}
+
+func TestGRPCStatusDetailPropMatcher_Match(t *testing.T) {
+	t.Run("should fail when actual is not the detail type", func(t *testing.T) {
+		matcher := &GRPCStatusDetailPropMatcher[*errdetails.RequestInfo]{}
+		gotResult, err := matcher.Match(&errdetails.ErrorInfo{})
+		assert.False(t, gotResult)
+		assert.ErrorIs(t, err, errUnexpectedDetailType)
+		assert.NotErrorIs(t, err, errStatusDetailNotFound)
+	})
+
+	t.Run("should match the property", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		matcher := &GRPCStatusDetailPropMatcher[*errdetails.RequestInfo]{
+			PropMap: func(detail *errdetails.RequestInfo) interface{} {
+				return detail.GetRequestId()
+			},
+			Matcher: gm,
+		}
+		gm.EXPECT().Match("rid").Return(true, nil)
+		gotResult, err := matcher.Match(&errdetails.RequestInfo{RequestId: "rid"})
+		assert.NoError(t, err)
+		assert.True(t, gotResult)
+	})
+}
+
+func TestGRPCStatusDetailPropMatcher_FailureMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	matcher := &GRPCStatusDetailPropMatcher[*errdetails.RequestInfo]{
+		PropMap: func(detail *errdetails.RequestInfo) interface{} {
+			return detail.GetRequestId()
+		},
+		Matcher: gm,
+	}
+	gm.EXPECT().FailureMessage("rid").Return(wantMessage)
+	assert.Equal(t, wantMessage, matcher.FailureMessage(&errdetails.RequestInfo{RequestId: "rid"}))
+	assert.Contains(t, matcher.FailureMessage("string"), "is not an *errdetails.RequestInfo")
+}
+
+func TestGRPCStatusDetailPropMatcher_NegatedFailureMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	matcher := &GRPCStatusDetailPropMatcher[*errdetails.RequestInfo]{
+		PropMap: func(detail *errdetails.RequestInfo) interface{} {
+			return detail.GetRequestId()
+		},
+		Matcher: gm,
+	}
+	gm.EXPECT().NegatedFailureMessage("rid").Return(wantMessage)
+	assert.Equal(t, wantMessage, matcher.NegatedFailureMessage(&errdetails.RequestInfo{RequestId: "rid"}))
+	assert.Contains(t, matcher.NegatedFailureMessage("string"), "is not an *errdetails.RequestInfo")
+}
+
+func Test_findStatusDetails(t *testing.T) {
+	st, err := status.New(codes.Internal, "message").WithDetails(
+		&errdetails.LocalizedMessage{Locale: "en-US", Message: "message"},
+		&errdetails.RequestInfo{RequestId: "rid"},
+		&errdetails.LocalizedMessage{Locale: "pt-BR", Message: "mensagem"},
+	)
+	require.NoError(t, err)
+
+	gotDetails := findStatusDetails[*errdetails.LocalizedMessage](st)
+	require.Len(t, gotDetails, 2)
+	assert.Equal(t, "en-US", gotDetails[0].GetLocale())
+	assert.Equal(t, "pt-BR", gotDetails[1].GetLocale())
+	assert.Empty(t, findStatusDetails[*errdetails.ErrorInfo](st))
+
+	t.Run("should find all the details when T is an interface", func(t *testing.T) {
+		gotDetails := findStatusDetails[proto.Message](st)
+		require.Len(t, gotDetails, 3)
+		assert.True(t, proto.Equal(&errdetails.RequestInfo{RequestId: "rid"}, gotDetails[1]))
+		assert.Equal(t, "protoreflect.ProtoMessage", detailTypeName[proto.Message]())
+	})
+}
+
+func Test_describeStatusDetails(t *testing.T) {
+	t.Run("should describe a status without details", func(t *testing.T) {
+		assert.Equal(t, "<no details>", describeStatusDetails(status.New(codes.Internal, "message")))
+	})
+
+	t.Run("should list the details with their type URLs", func(t *testing.T) {
+		st, err := status.New(codes.Internal, "message").WithDetails(
+			&errdetails.RequestInfo{RequestId: "rid"},
+			wrapperspb.String("custom detail"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, `type.googleapis.com/google.rpc.RequestInfo: {"requestId":"rid"}
+type.googleapis.com/google.protobuf.StringValue: "custom detail"`, describeStatusDetails(st))
+	})
+
+	t.Run("should use the status details format", func(t *testing.T) {
+		defer SetStatusDetailsFormat(SetStatusDetailsFormat(DetailFormatText))
+		st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+		require.NoError(t, err)
+		assert.Regexp(t, `^type.googleapis.com/google.rpc.RequestInfo: \{request_id:\s*"rid"\}$`, describeStatusDetails(st))
+	})
+
+	t.Run("should describe unknown detail types", func(t *testing.T) {
+		st := status.FromProto(&spb.Status{
+			Code:    int32(codes.Internal),
+			Details: []*anypb.Any{{TypeUrl: "type.googleapis.com/unknown.Detail", Value: []byte{0x08, 0x01}}},
+		})
+		assert.Equal(t, "type.googleapis.com/unknown.Detail: <unknown type> 0801 (2 bytes)", describeStatusDetails(st))
+	})
+}
@@ -0,0 +1,70 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errUndecodableStatusDetail = errors.New("undecodable status details")
+)
+
+// GRPCStatusDetailsMatcher implements a StatusMatcher that applies the Matcher to all the details of the given
+// status.Status, decoded as a []proto.Message. Any collection matcher can be used (eg: ConsistOf or ContainElement).
+//
+// Unlike status.Details, details whose types cannot be decoded are not handed to the Matcher as errors. Instead, the
+// match fails with an error listing them.
+//
+// The failure messages render the details as describeStatusDetails does, with their type URLs.
+type GRPCStatusDetailsMatcher struct {
+	Matcher types.GomegaMatcher
+}
+
+func (m *GRPCStatusDetailsMatcher) Match(st *status.Status) (bool, error) {
+	details, err := decodeStatusDetails(st)
+	if err != nil {
+		return false, err
+	}
+	return m.Matcher.Match(details)
+}
+
+func (m *GRPCStatusDetailsMatcher) FailureMessage(st *status.Status) string {
+	details, err := decodeStatusDetails(st)
+	if err != nil {
+		return err.Error()
+	}
+	return propFailureMessage(m.Matcher, details, false)
+}
+
+func (m *GRPCStatusDetailsMatcher) NegatedFailureMessage(st *status.Status) string {
+	details, err := decodeStatusDetails(st)
+	if err != nil {
+		return err.Error()
+	}
+	return propFailureMessage(m.Matcher, details, true)
+}
+
+// decodeStatusDetails decodes all the details of st. If any of them cannot be decoded (eg: its type is not
+// registered), it returns an error describing each of them.
+func decodeStatusDetails(st *status.Status) ([]proto.Message, error) {
+	anyDetails := st.Proto().GetDetails()
+	details := make([]proto.Message, 0, len(anyDetails))
+	var undecodable []string
+	for i, anyDetail := range anyDetails {
+		detail, err := anyDetail.UnmarshalNew()
+		if err != nil {
+			undecodable = append(undecodable, fmt.Sprintf("details[%d] %s: %s", i, anyDetail.GetTypeUrl(), err))
+			continue
+		}
+		details = append(details, detail)
+	}
+	if len(undecodable) > 0 {
+		return nil, fmt.Errorf("%w:\n%s", errUndecodableStatusDetail, strings.Join(undecodable, "\n"))
+	}
+	return details, nil
+}
@@ -0,0 +1,109 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newUndecodableStatus() *status.Status {
+	return status.FromProto(&spb.Status{
+		Code: int32(codes.Internal),
+		Details: []*anypb.Any{
+			{TypeUrl: "type.googleapis.com/unknown.Detail"},
+		},
+	})
+}
+
+func Test_decodeStatusDetails(t *testing.T) {
+	t.Run("should decode the details", func(t *testing.T) {
+		st, err := status.New(codes.Internal, "message").WithDetails(
+			&errdetails.RequestInfo{RequestId: "rid"},
+			&errdetails.ErrorInfo{Reason: "reason"},
+		)
+		require.NoError(t, err)
+		gotDetails, err := decodeStatusDetails(st)
+		require.NoError(t, err)
+		require.Len(t, gotDetails, 2)
+		assert.True(t, proto.Equal(&errdetails.RequestInfo{RequestId: "rid"}, gotDetails[0]))
+		assert.True(t, proto.Equal(&errdetails.ErrorInfo{Reason: "reason"}, gotDetails[1]))
+	})
+
+	t.Run("should decode a status without details", func(t *testing.T) {
+		gotDetails, err := decodeStatusDetails(status.New(codes.Internal, "message"))
+		require.NoError(t, err)
+		assert.Empty(t, gotDetails)
+	})
+
+	t.Run("should fail on undecodable details", func(t *testing.T) {
+		gotDetails, err := decodeStatusDetails(newUndecodableStatus())
+		assert.ErrorIs(t, err, errUndecodableStatusDetail)
+		assert.Contains(t, err.Error(), "details[0] type.googleapis.com/unknown.Detail")
+		assert.Nil(t, gotDetails)
+	})
+}
+
+func TestGRPCStatusDetailsMatcher_Match(t *testing.T) {
+	t.Run("should match the decoded details", func(t *testing.T) {
+		st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		gm.EXPECT().Match(gomock.Len(1)).Return(true, nil)
+
+		gotSuccess, err := (&GRPCStatusDetailsMatcher{Matcher: gm}).Match(st)
+		assert.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should fail on undecodable details", func(t *testing.T) {
+		gotSuccess, err := (&GRPCStatusDetailsMatcher{}).Match(newUndecodableStatus())
+		assert.ErrorIs(t, err, errUndecodableStatusDetail)
+		assert.False(t, gotSuccess)
+	})
+}
+
+func TestGRPCStatusDetailsMatcher_FailureMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	gm.EXPECT().FailureMessage(gomock.Len(0)).Return(wantMessage)
+
+	matcher := &GRPCStatusDetailsMatcher{Matcher: gm}
+	assert.Equal(t, wantMessage, matcher.FailureMessage(status.New(codes.Internal, "message")))
+	assert.Contains(t, matcher.FailureMessage(newUndecodableStatus()), "undecodable status details")
+}
+
+func TestGRPCStatusDetailsMatcher_NegatedFailureMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gm := NewMockGomegaMatcher(ctrl)
+	gm.EXPECT().NegatedFailureMessage(gomock.Len(0)).Return(wantMessage)
+
+	matcher := &GRPCStatusDetailsMatcher{Matcher: gm}
+	assert.Equal(t, wantMessage, matcher.NegatedFailureMessage(status.New(codes.Internal, "message")))
+	assert.Contains(t, matcher.NegatedFailureMessage(newUndecodableStatus()), "undecodable status details")
+}
+
+func TestGRPCStatusDetailsMatcher_FailureMessage_details(t *testing.T) {
+	st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+	require.NoError(t, err)
+
+	matcher := &GRPCStatusDetailsMatcher{Matcher: gomega.BeEmpty()}
+	gotMessage := matcher.FailureMessage(st)
+	assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.RequestInfo: {"requestId":"rid"}`)
+	assert.Contains(t, gotMessage, "to be empty")
+	assert.NotContains(t, gotMessage, "sizeCache")
+
+	gotMessage = (&GRPCStatusDetailsMatcher{Matcher: gomega.HaveLen(1)}).NegatedFailureMessage(st)
+	assert.Contains(t, gotMessage, `type.googleapis.com/google.rpc.RequestInfo: {"requestId":"rid"}`)
+	assert.NotContains(t, gotMessage, "sizeCache")
+}
@@ -0,0 +1,84 @@
+package matchersimpl
+
+import (
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/grpc/status"
+)
+
+// StatusFields holds the matchers applied to each part of a status.Status. A nil matcher skips the part.
+type StatusFields struct {
+	// Code is matched against the codes.Code of the status.
+	Code types.GomegaMatcher
+	// Message is matched against the message of the status.
+	Message types.GomegaMatcher
+	// Details is matched against the decoded details of the status, as a []proto.Message. Details that cannot be
+	// decoded are reported as a failure.
+	Details types.GomegaMatcher
+}
+
+// GRPCStatusFieldsMatcher implements a StatusMatcher that matches all the StatusFields at once, reporting all the
+// parts that did not match in a single failure.
+type GRPCStatusFieldsMatcher struct {
+	Fields StatusFields
+
+	failures []string
+}
+
+// statusField is a part of the status.Status with its name, used on the failure messages. When set, validate is
+// called before the matcher and its error is reported as the failure of the part.
+type statusField struct {
+	name     string
+	matcher  *GRPCStatusPropMatcher
+	validate func(st *status.Status) error
+}
+
+func (m *GRPCStatusFieldsMatcher) fields() []statusField {
+	var fields []statusField
+	add := func(name string, matcher types.GomegaMatcher, propMap func(st *status.Status) interface{}, validate func(st *status.Status) error) {
+		if matcher == nil {
+			return
+		}
+		fields = append(fields, statusField{name: name, matcher: &GRPCStatusPropMatcher{PropMap: propMap, Matcher: matcher}, validate: validate})
+	}
+	add("code", m.Fields.Code, func(st *status.Status) interface{} { return st.Code() }, nil)
+	add("message", m.Fields.Message, func(st *status.Status) interface{} { return st.Message() }, nil)
+	add("details", m.Fields.Details, func(st *status.Status) interface{} {
+		details, _ := decodeStatusDetails(st)
+		return details
+	}, func(st *status.Status) error {
+		_, err := decodeStatusDetails(st)
+		return err
+	})
+	return fields
+}
+
+func (m *GRPCStatusFieldsMatcher) Match(st *status.Status) (bool, error) {
+	m.failures = nil
+	for _, field := range m.fields() {
+		if field.validate != nil {
+			if err := field.validate(st); err != nil {
+				m.failures = append(m.failures, formatFieldFailure(field.name, err.Error()))
+				continue
+			}
+		}
+		success, err := field.matcher.Match(st)
+		switch {
+		case err != nil:
+			m.failures = append(m.failures, formatFieldFailure(field.name, err.Error()))
+		case !success:
+			m.failures = append(m.failures, formatFieldFailure(field.name, propFailureMessage(field.matcher.Matcher, field.matcher.PropMap(st), false)))
+		}
+	}
+	return len(m.failures) == 0, nil
+}
+
+func (m *GRPCStatusFieldsMatcher) FailureMessage(st *status.Status) string {
+	return format.Message(FormatStatus(st), "to match status fields") + "\n" + strings.Join(m.failures, "\n")
+}
+
+func (m *GRPCStatusFieldsMatcher) NegatedFailureMessage(st *status.Status) string {
+	return format.Message(FormatStatus(st), "not to match status fields")
+}
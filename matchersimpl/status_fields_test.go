@@ -0,0 +1,118 @@
+package matchersimpl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatusFieldsMatcher_Match(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		fields       StatusFields
+		wantSuccess  bool
+		wantFailures []string
+	}{
+		{"should match all the fields", StatusFields{
+			Code:    gomega.Equal(codes.InvalidArgument),
+			Message: gomega.Equal("invalid book"),
+			Details: gomega.HaveLen(1),
+		}, true, nil},
+		{"should match without matchers", StatusFields{}, true, nil},
+		{"should report the mismatched fields", StatusFields{
+			Code:    gomega.Equal(codes.NotFound),
+			Message: gomega.Equal("invalid book"),
+			Details: gomega.BeEmpty(),
+		}, false, []string{"  code:\n", "  details:\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher := &GRPCStatusFieldsMatcher{Fields: tt.fields}
+			gotSuccess, err := matcher.Match(st)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, gotSuccess)
+			require.Len(t, matcher.failures, len(tt.wantFailures))
+			for i, wantFailure := range tt.wantFailures {
+				assert.True(t, strings.HasPrefix(matcher.failures[i], wantFailure), matcher.failures[i])
+			}
+		})
+	}
+
+	t.Run("should report the errors of the matchers as failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		gm.EXPECT().Match("invalid book").Return(false, errors.New("matcher error"))
+		matcher := &GRPCStatusFieldsMatcher{Fields: StatusFields{Message: gm}}
+		gotSuccess, err := matcher.Match(st)
+		assert.NoError(t, err)
+		assert.False(t, gotSuccess)
+		require.Len(t, matcher.failures, 1)
+		assert.Contains(t, matcher.failures[0], "matcher error")
+	})
+}
+
+func TestGRPCStatusFieldsMatcher_FailureMessage(t *testing.T) {
+	st := status.New(codes.InvalidArgument, "invalid book")
+	matcher := &GRPCStatusFieldsMatcher{Fields: StatusFields{
+		Code:    gomega.Equal(codes.NotFound),
+		Message: gomega.Equal("invalid author"),
+	}}
+	gotSuccess, err := matcher.Match(st)
+	require.NoError(t, err)
+	require.False(t, gotSuccess)
+
+	gotMessage := matcher.FailureMessage(st)
+	assert.Contains(t, gotMessage, "Status: InvalidArgument,")
+	assert.Contains(t, gotMessage, "Message: invalid book,")
+	assert.NotContains(t, gotMessage, "sizeCache")
+	assert.Contains(t, gotMessage, "to match status fields")
+	assert.Contains(t, gotMessage, "  code:\n")
+	assert.Contains(t, gotMessage, "  message:\n")
+	assert.Contains(t, gotMessage, "invalid author")
+}
+
+func TestGRPCStatusFieldsMatcher_FailureMessage_details(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(&errdetails.RequestInfo{RequestId: "rid"})
+	require.NoError(t, err)
+	matcher := &GRPCStatusFieldsMatcher{Fields: StatusFields{
+		Code:    gomega.Equal(codes.NotFound),
+		Details: gomega.BeEmpty(),
+	}}
+	gotSuccess, err := matcher.Match(st)
+	require.NoError(t, err)
+	require.False(t, gotSuccess)
+
+	gotMessage := matcher.FailureMessage(st)
+	assert.Contains(t, gotMessage, "InvalidArgument (3)")
+	assert.Contains(t, gotMessage, "NotFound (5)")
+	assert.Contains(t, gotMessage, "type.googleapis.com/google.rpc.RequestInfo")
+	assert.NotContains(t, gotMessage, "sizeCache")
+	assert.NotContains(t, gotMessage, "atomicMessageInfo")
+}
+
+func TestGRPCStatusFieldsMatcher_NegatedFailureMessage(t *testing.T) {
+	gotMessage := (&GRPCStatusFieldsMatcher{}).NegatedFailureMessage(status.New(codes.InvalidArgument, "invalid book"))
+	assert.Contains(t, gotMessage, "Status: InvalidArgument,")
+	assert.Contains(t, gotMessage, "Message: invalid book,")
+	assert.Contains(t, gotMessage, "not to match status fields")
+}
+
+func TestGRPCStatusFieldsMatcher_undecodableDetails(t *testing.T) {
+	matcher := &GRPCStatusFieldsMatcher{Fields: StatusFields{Details: gomega.BeEmpty()}}
+	gotSuccess, err := matcher.Match(newUndecodableStatus())
+	assert.NoError(t, err)
+	assert.False(t, gotSuccess)
+	require.Len(t, matcher.failures, 1)
+	assert.Contains(t, matcher.failures[0], "undecodable status details")
+}
@@ -6,10 +6,14 @@ package matchersimpl
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -59,6 +63,52 @@ func TestGRPCStatusMatcher_Match(t *testing.T) {
 	}
 }
 
+func Test_statusFromActual(t *testing.T) {
+	wantStatus := status.New(codes.NotFound, "not found")
+
+	tests := []struct {
+		name       string
+		givenValue interface{}
+		wantErr    error
+	}{
+		{"should accept a grpc error", wantStatus.Err(), nil},
+		{"should accept a wrapped grpc error", fmt.Errorf("fetching book: %w", wantStatus.Err()), nil},
+		{"should accept a *status.Status", wantStatus, nil},
+		{"should accept a *spb.Status", wantStatus.Proto(), nil},
+		{"should reject a non grpc error", errors.New("non status error"), errStatusErrExpected},
+		{"should reject other values", "not found", errExpectedError},
+		{"should reject nil", nil, errExpectedError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStatus, err := statusFromActual(tt.givenValue)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, gotStatus)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, codes.NotFound, gotStatus.Code())
+			assert.Equal(t, "not found", gotStatus.Message())
+		})
+	}
+
+	t.Run("should name the received type", func(t *testing.T) {
+		_, err := statusFromActual(42)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "got int")
+	})
+}
+
+func TestStatusFrom(t *testing.T) {
+	gotStatus, ok := StatusFrom(fmt.Errorf("fetching book: %w", status.Error(codes.NotFound, "not found")))
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, gotStatus.Code())
+
+	_, ok = StatusFrom(errors.New("non status error"))
+	assert.False(t, ok)
+}
+
 func TestGRPCStatusMatcher_validateActual(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -71,6 +121,7 @@ func TestGRPCStatusMatcher_validateActual(t *testing.T) {
 		{"should fail when no error given", false, codes.OK, "is not an error", nil, false},
 		{"should fail when no status given", errors.New("non status error"), codes.OK, "is not a grpc error", nil, false},
 		{"should validate", status.New(codes.InvalidArgument, "invalid argument").Err(), codes.NotFound, "", status.New(codes.InvalidArgument, "invalid argument"), true},
+		{"should validate a *status.Status", status.New(codes.InvalidArgument, "invalid argument"), codes.NotFound, "", status.New(codes.InvalidArgument, "invalid argument"), true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -149,9 +200,9 @@ func TestGRPCStatusPropMatcher_FailureMessage(t *testing.T) {
 		},
 		Matcher: gm,
 	}
-	gm.EXPECT().FailureMessage(wantST.Code()).Return(wantMessage)
+	gm.EXPECT().FailureMessage(statusCode(codes.Internal)).Return(wantMessage)
 	gotMessage := matcher.FailureMessage(wantST)
-	assert.Equal(t, wantMessage, gotMessage)
+	assert.Equal(t, wantMessage+"\n"+formatStatus(wantST), gotMessage)
 }
 
 func TestGRPCStatusPropMatcher_NegatedFailureMessage(t *testing.T) {
@@ -166,7 +217,30 @@ func TestGRPCStatusPropMatcher_NegatedFailureMessage(t *testing.T) {
 		},
 		Matcher: gm,
 	}
-	gm.EXPECT().NegatedFailureMessage(wantST.Code()).Return(wantMessage)
+	gm.EXPECT().NegatedFailureMessage(statusCode(codes.Internal)).Return(wantMessage)
 	gotMessage := matcher.NegatedFailureMessage(wantST)
-	assert.Equal(t, wantMessage, gotMessage)
+	assert.Equal(t, wantMessage+"\n"+formatStatus(wantST), gotMessage)
+}
+
+func TestGRPCStatusPropMatcher_FailureMessage_code(t *testing.T) {
+	st := status.New(codes.InvalidArgument, "message")
+	matcher := GRPCStatusPropMatcher{
+		PropMap: func(st *status.Status) interface{} {
+			return st.Code()
+		},
+		Matcher: gomega.Equal(codes.NotFound),
+	}
+	gotMessage := matcher.FailureMessage(st)
+	assert.Contains(t, gotMessage, "<matchersimpl.statusCode>: InvalidArgument (3)\nto equal\n    <matchersimpl.statusCode>: NotFound (5)")
+	assert.Contains(t, matcher.NegatedFailureMessage(st), "not to equal")
+
+	matcher.Matcher = gomega.BeNumerically(">", codes.NotFound)
+	assert.Contains(t, matcher.FailureMessage(st), "InvalidArgument (3)")
+}
+
+func Test_formatStatus(t *testing.T) {
+	gotMessage := formatStatus(status.New(codes.Internal, "random message"))
+	assert.True(t, strings.HasPrefix(gotMessage, "Status:\n    {\n"), gotMessage)
+	assert.Contains(t, gotMessage, "Status: Internal,")
+	assert.Contains(t, gotMessage, "Message: random message,")
 }
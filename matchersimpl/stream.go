@@ -0,0 +1,254 @@
+package matchersimpl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errExpectedStream        = errors.New("the given object is not a stream, a Recv function or a *StreamResult")
+	errStreamTimeout         = errors.New("the stream did not end in time")
+	errStreamMessageNotProto = errors.New("the stream received a message that is not a proto.Message")
+
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// StreamResult holds everything received from a stream, until it ended or the timeout elapsed.
+type StreamResult struct {
+	// Messages are the messages received, in order.
+	Messages []proto.Message
+	// Status is the status the stream ended with. Streams ended by io.EOF have the OK status. It is nil when the stream
+	// did not end in time.
+	Status *status.Status
+	// Timeout is how long the stream was waited for.
+	Timeout time.Duration
+}
+
+// TimedOut reports whether the stream did not end in time.
+func (r *StreamResult) TimedOut() bool {
+	return r.Status == nil
+}
+
+// GomegaString renders the received messages, one per line, followed by the status the stream ended with.
+func (r *StreamResult) GomegaString() string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if len(r.Messages) == 0 {
+		fmt.Fprintf(&sb, "%sMessages: [],\n", format.Indent)
+	} else {
+		fmt.Fprintf(&sb, "%sMessages: [\n", format.Indent)
+		for _, m := range r.Messages {
+			fmt.Fprintf(&sb, "%s%s%s,\n", format.Indent, format.Indent, FormatMessage(m, DetailFormatJSON))
+		}
+		fmt.Fprintf(&sb, "%s],\n", format.Indent)
+	}
+	if r.TimedOut() {
+		fmt.Fprintf(&sb, "%sStatus: <did not end within %s>,\n", format.Indent, r.Timeout)
+	} else {
+		fmt.Fprintf(&sb, "%sStatus: %s (%d) %q,\n", format.Indent, r.Status.Code(), r.Status.Code(), r.Status.Message())
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// CancelableStream pairs a stream with the function that cancels its context (eg: the one returned by
+// context.WithCancel for the context given to the RPC). When the timeout elapses, DrainStream and Conversation call
+// Cancel, so the blocked Recv returns and the goroutine receiving from the stream stops.
+type CancelableStream struct {
+	Stream interface{}
+	Cancel func()
+}
+
+// unwrapCancelableStream returns the stream wrapped by a *CancelableStream, and its cancel function. Other values are
+// returned as they are, with a nil cancel function.
+func unwrapCancelableStream(actual interface{}) (interface{}, func()) {
+	if cancelable, ok := actual.(*CancelableStream); ok {
+		return cancelable.Stream, cancelable.Cancel
+	}
+	return actual, nil
+}
+
+// recvFunc returns the function that receives the next message of actual. Supported values are functions with the
+// signature func() (T, error), such as the Recv method of the generated streams, and any value that has such a Recv
+// method (eg: pb.Service_MethodClient).
+func recvFunc(actual interface{}) (func() (interface{}, error), error) {
+	if actual == nil {
+		return nil, fmt.Errorf("%w: got nil", errExpectedStream)
+	}
+	fn := reflect.ValueOf(actual)
+	if fn.Kind() != reflect.Func {
+		fn = fn.MethodByName("Recv")
+	}
+	if !fn.IsValid() || !isRecvSignature(fn.Type()) {
+		return nil, fmt.Errorf("%w: got %T", errExpectedStream, actual)
+	}
+	return func() (interface{}, error) {
+		out := fn.Call(nil)
+		err, _ := out[1].Interface().(error)
+		return out[0].Interface(), err
+	}, nil
+}
+
+func isRecvSignature(t reflect.Type) bool {
+	return t.NumIn() == 0 && t.NumOut() == 2 && t.Out(1) == errorType
+}
+
+// DrainStream receives all the messages of the stream (see recvFunc) until it ends or the timeout elapses. A
+// *StreamResult is returned as it is, so the same stream can be checked by several matchers.
+//
+// When the timeout elapses on a *CancelableStream, its context is canceled and DrainStream waits, for up to another
+// timeout, for the goroutine receiving from the stream to stop. Other streams leave that goroutine blocked until their
+// context is canceled by the caller.
+func DrainStream(actual interface{}, timeout time.Duration) (*StreamResult, error) {
+	if result, ok := actual.(*StreamResult); ok {
+		return result, nil
+	}
+	actual, cancel := unwrapCancelableStream(actual)
+	recv, err := recvFunc(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu       sync.Mutex
+		messages []proto.Message
+	)
+	done := make(chan error, 1)
+	go func() {
+		for {
+			m, err := recv()
+			if err != nil {
+				done <- err
+				return
+			}
+			msg, ok := m.(proto.Message)
+			if !ok {
+				done <- fmt.Errorf("%w: got %T", errStreamMessageNotProto, m)
+				return
+			}
+			mu.Lock()
+			messages = append(messages, msg)
+			mu.Unlock()
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	result := &StreamResult{Timeout: timeout}
+	select {
+	case err := <-done:
+		if errors.Is(err, errStreamMessageNotProto) {
+			return nil, err
+		}
+		if !errors.Is(err, io.EOF) {
+			result.Status = status.Convert(err)
+		} else {
+			result.Status = status.New(codes.OK, "")
+		}
+	case <-timer.C:
+		if cancel != nil {
+			cancel()
+			stopTimer := time.NewTimer(timeout)
+			defer stopTimer.Stop()
+			select {
+			case <-done:
+			case <-stopTimer.C:
+			}
+		}
+	}
+	mu.Lock()
+	result.Messages = append([]proto.Message(nil), messages...)
+	mu.Unlock()
+	return result, nil
+}
+
+// GRPCStreamMatcher drains the given stream (see DrainStream) and applies the Matcher to the part of the result
+// selected by Part (eg: the messages or the status).
+//
+// Streams that do not end within the Timeout fail the match with an error, whether the matcher is negated or not.
+type GRPCStreamMatcher struct {
+	Timeout time.Duration
+	Part    func(result *StreamResult) interface{}
+	Matcher types.GomegaMatcher
+
+	result *StreamResult
+}
+
+func (m *GRPCStreamMatcher) Match(actual interface{}) (bool, error) {
+	result, err := DrainStream(actual, m.Timeout)
+	if err != nil {
+		return false, err
+	}
+	m.result = result
+	if result.TimedOut() {
+		return false, fmt.Errorf("%w: %s", errStreamTimeout, format.Message(result, fmt.Sprintf("to end within %s", m.Timeout)))
+	}
+	return m.Matcher.Match(m.Part(result))
+}
+
+func (m *GRPCStreamMatcher) FailureMessage(_ interface{}) string {
+	return m.Matcher.FailureMessage(m.Part(m.result)) + "\n" + describeStream(m.result)
+}
+
+func (m *GRPCStreamMatcher) NegatedFailureMessage(_ interface{}) string {
+	return m.Matcher.NegatedFailureMessage(m.Part(m.result)) + "\n" + describeStream(m.result)
+}
+
+func describeStream(result *StreamResult) string {
+	return "Received from the stream:\n" + format.Object(result, 1)
+}
+
+// GRPCStreamEndMatcher succeeds if the stream ends within the Timeout. Then, the drained *StreamResult is given to all
+// the Matchers, so several stream matchers can check the same stream.
+type GRPCStreamEndMatcher struct {
+	Timeout  time.Duration
+	Matchers []types.GomegaMatcher
+
+	result  *StreamResult
+	failure types.GomegaMatcher
+}
+
+func (m *GRPCStreamEndMatcher) Match(actual interface{}) (bool, error) {
+	result, err := DrainStream(actual, m.Timeout)
+	if err != nil {
+		return false, err
+	}
+	m.result = result
+	m.failure = nil
+	if result.TimedOut() {
+		return false, nil
+	}
+	for _, matcher := range m.Matchers {
+		success, err := matcher.Match(result)
+		if err != nil {
+			return false, err
+		}
+		if !success {
+			m.failure = matcher
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *GRPCStreamEndMatcher) FailureMessage(_ interface{}) string {
+	if m.failure != nil {
+		return m.failure.FailureMessage(m.result)
+	}
+	return format.Message(m.result, fmt.Sprintf("to end within %s", m.Timeout))
+}
+
+func (m *GRPCStreamEndMatcher) NegatedFailureMessage(_ interface{}) string {
+	return format.Message(m.result, fmt.Sprintf("not to end within %s", m.Timeout))
+}
@@ -0,0 +1,227 @@
+package matchersimpl
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeStream stands for a generated client stream, receiving the messages and then the err. If block is set, it blocks
+// until block is closed before returning the err. ended is set once the err is returned.
+type fakeStream struct {
+	messages []*errdetails.ErrorInfo
+	err      error
+	block    <-chan struct{}
+	ended    int32
+}
+
+func (s *fakeStream) Recv() (*errdetails.ErrorInfo, error) {
+	if len(s.messages) > 0 {
+		m := s.messages[0]
+		s.messages = s.messages[1:]
+		return m, nil
+	}
+	if s.block != nil {
+		<-s.block
+	}
+	atomic.StoreInt32(&s.ended, 1)
+	return nil, s.err
+}
+
+func newFakeStream(err error, reasons ...string) *fakeStream {
+	stream := &fakeStream{err: err}
+	for _, reason := range reasons {
+		stream.messages = append(stream.messages, &errdetails.ErrorInfo{Reason: reason})
+	}
+	return stream
+}
+
+func TestDrainStream(t *testing.T) {
+	t.Run("should drain a stream ended by io.EOF", func(t *testing.T) {
+		result, err := DrainStream(newFakeStream(io.EOF, "a", "b"), time.Second)
+		require.NoError(t, err)
+		require.Len(t, result.Messages, 2)
+		assert.True(t, proto.Equal(&errdetails.ErrorInfo{Reason: "a"}, result.Messages[0]))
+		assert.True(t, proto.Equal(&errdetails.ErrorInfo{Reason: "b"}, result.Messages[1]))
+		assert.False(t, result.TimedOut())
+		assert.Equal(t, codes.OK, result.Status.Code())
+	})
+
+	t.Run("should drain a stream ended by an error", func(t *testing.T) {
+		result, err := DrainStream(newFakeStream(status.Error(codes.NotFound, "not found"), "a"), time.Second)
+		require.NoError(t, err)
+		assert.Len(t, result.Messages, 1)
+		assert.Equal(t, codes.NotFound, result.Status.Code())
+		assert.Equal(t, "not found", result.Status.Message())
+	})
+
+	t.Run("should drain a Recv function", func(t *testing.T) {
+		result, err := DrainStream(newFakeStream(io.EOF, "a").Recv, time.Second)
+		require.NoError(t, err)
+		assert.Len(t, result.Messages, 1)
+	})
+
+	t.Run("should stop draining when the timeout elapses", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		stream := newFakeStream(io.EOF, "a")
+		stream.block = block
+
+		result, err := DrainStream(stream, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, result.TimedOut())
+		assert.Len(t, result.Messages, 1)
+	})
+
+	t.Run("should cancel a CancelableStream and stop receiving when the timeout elapses", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := newFakeStream(status.FromContextError(context.Canceled).Err(), "a")
+		stream.block = ctx.Done()
+
+		result, err := DrainStream(&CancelableStream{Stream: stream, Cancel: cancel}, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.True(t, result.TimedOut())
+		assert.Len(t, result.Messages, 1)
+		assert.Error(t, ctx.Err())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&stream.ended))
+	})
+
+	t.Run("should drain a CancelableStream that ends in time", func(t *testing.T) {
+		canceled := false
+		result, err := DrainStream(&CancelableStream{Stream: newFakeStream(io.EOF, "a"), Cancel: func() { canceled = true }}, time.Second)
+		require.NoError(t, err)
+		assert.Len(t, result.Messages, 1)
+		assert.Equal(t, codes.OK, result.Status.Code())
+		assert.False(t, canceled)
+	})
+
+	t.Run("should return a *StreamResult as it is", func(t *testing.T) {
+		want := &StreamResult{Status: status.New(codes.OK, "")}
+		got, err := DrainStream(want, time.Second)
+		require.NoError(t, err)
+		assert.Same(t, want, got)
+	})
+
+	t.Run("should fail on values that are not streams", func(t *testing.T) {
+		for _, actual := range []interface{}{nil, "stream", func() error { return nil }} {
+			_, err := DrainStream(actual, time.Second)
+			assert.ErrorIs(t, err, errExpectedStream)
+		}
+	})
+
+	t.Run("should fail on messages that are not protos", func(t *testing.T) {
+		_, err := DrainStream(func() (string, error) { return "message", nil }, time.Second)
+		assert.ErrorIs(t, err, errStreamMessageNotProto)
+	})
+}
+
+func TestStreamResult_GomegaString(t *testing.T) {
+	t.Run("should render the messages and the status", func(t *testing.T) {
+		result := &StreamResult{
+			Messages: []proto.Message{&errdetails.ErrorInfo{Reason: "a"}},
+			Status:   status.New(codes.NotFound, "not found"),
+		}
+		assert.Equal(t, `{
+    Messages: [
+        {"reason":"a"},
+    ],
+    Status: NotFound (5) "not found",
+}`, result.GomegaString())
+	})
+
+	t.Run("should render the timeout", func(t *testing.T) {
+		result := &StreamResult{Timeout: time.Second}
+		assert.Equal(t, `{
+    Messages: [],
+    Status: <did not end within 1s>,
+}`, result.GomegaString())
+	})
+}
+
+func TestGRPCStreamMatcher_Match(t *testing.T) {
+	messages := func(result *StreamResult) interface{} { return result.Messages }
+
+	t.Run("should apply the matcher to the part of the stream", func(t *testing.T) {
+		matcher := &GRPCStreamMatcher{Timeout: time.Second, Part: messages, Matcher: gomega.HaveLen(2)}
+		gotSuccess, err := matcher.Match(newFakeStream(io.EOF, "a", "b"))
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should describe the stream on failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		gm.EXPECT().Match(gomock.Any()).Return(false, nil)
+		gm.EXPECT().FailureMessage(gomock.Any()).Return("failure message")
+
+		matcher := &GRPCStreamMatcher{Timeout: time.Second, Part: messages, Matcher: gm}
+		stream := newFakeStream(io.EOF, "a")
+		gotSuccess, err := matcher.Match(stream)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		gotMessage := matcher.FailureMessage(stream)
+		assert.Contains(t, gotMessage, "failure message\nReceived from the stream:\n")
+		assert.Contains(t, gotMessage, `{"reason":"a"}`)
+	})
+
+	t.Run("should fail with an error when the stream does not end in time", func(t *testing.T) {
+		stream := newFakeStream(io.EOF)
+		block := make(chan struct{})
+		defer close(block)
+		stream.block = block
+
+		ctrl := gomock.NewController(t)
+		gm := NewMockGomegaMatcher(ctrl)
+		_, err := (&GRPCStreamMatcher{Timeout: 10 * time.Millisecond, Part: messages, Matcher: gm}).Match(stream)
+		assert.ErrorIs(t, err, errStreamTimeout)
+	})
+}
+
+func TestGRPCStreamEndMatcher_Match(t *testing.T) {
+	t.Run("should match a stream that ends in time", func(t *testing.T) {
+		gotSuccess, err := (&GRPCStreamEndMatcher{Timeout: time.Second}).Match(newFakeStream(io.EOF, "a"))
+		require.NoError(t, err)
+		assert.True(t, gotSuccess)
+	})
+
+	t.Run("should not match a stream that does not end in time", func(t *testing.T) {
+		stream := newFakeStream(io.EOF)
+		block := make(chan struct{})
+		defer close(block)
+		stream.block = block
+
+		matcher := &GRPCStreamEndMatcher{Timeout: 10 * time.Millisecond}
+		gotSuccess, err := matcher.Match(stream)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		assert.Contains(t, matcher.FailureMessage(stream), "to end within 10ms")
+	})
+
+	t.Run("should give the drained stream to the matchers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		first, second := NewMockGomegaMatcher(ctrl), NewMockGomegaMatcher(ctrl)
+		first.EXPECT().Match(gomock.AssignableToTypeOf(&StreamResult{})).Return(true, nil)
+		second.EXPECT().Match(gomock.AssignableToTypeOf(&StreamResult{})).Return(false, nil)
+		second.EXPECT().FailureMessage(gomock.AssignableToTypeOf(&StreamResult{})).Return("failure message")
+
+		stream := newFakeStream(io.EOF, "a")
+		matcher := &GRPCStreamEndMatcher{Timeout: time.Second, Matchers: []types.GomegaMatcher{first, second}}
+		gotSuccess, err := matcher.Match(stream)
+		require.NoError(t, err)
+		assert.False(t, gotSuccess)
+		assert.Equal(t, "failure message", matcher.FailureMessage(stream))
+	})
+}
@@ -0,0 +1,75 @@
+package matchersimpl
+
+import (
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+// closestName returns the candidate closest to name, by edit distance, to be suggested when name looks mistyped. It
+// returns false if name is one of the candidates or if no candidate is close enough.
+func closestName(name string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+		if distance == 0 {
+			return "", false
+		}
+		if bestDistance == -1 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	maxDistance := len([]rune(name)) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	if bestDistance == -1 || bestDistance > maxDistance {
+		return "", false
+	}
+	return best, true
+}
+
+// editDistance computes the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// expectedString returns the string expected by an Equal or a GRPCFieldPathMatcher matcher. It is used to suggest
+// names when the matcher fails, as other matchers cannot tell which value they expected.
+func expectedString(matcher types.GomegaMatcher) (string, bool) {
+	switch m := matcher.(type) {
+	case *matchers.EqualMatcher:
+		s, ok := m.Expected.(string)
+		return s, ok
+	case *GRPCFieldPathMatcher:
+		return normalizeFieldPath(m.Expected), true
+	default:
+		return "", false
+	}
+}
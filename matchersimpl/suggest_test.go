@@ -0,0 +1,56 @@
+package matchersimpl
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_closestName(t *testing.T) {
+	candidates := []string{"book.title", "book.isbn", "book.author_name"}
+
+	tests := []struct {
+		name        string
+		given       string
+		wantClosest string
+		wantOK      bool
+	}{
+		{"should suggest a mistyped name", "book.tilte", "book.title", true},
+		{"should suggest a name with a missing character", "book.autor_name", "book.author_name", true},
+		{"should not suggest when the name exists", "book.title", "", false},
+		{"should not suggest a name that is too different", "shelf.location", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClosest, gotOK := closestName(tt.given, candidates)
+			assert.Equal(t, tt.wantClosest, gotClosest)
+			assert.Equal(t, tt.wantOK, gotOK)
+		})
+	}
+
+	t.Run("should not suggest without candidates", func(t *testing.T) {
+		_, gotOK := closestName("book.title", nil)
+		assert.False(t, gotOK)
+	})
+}
+
+func Test_editDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("title", "title"))
+	assert.Equal(t, 2, editDistance("title", "tilte"))
+	assert.Equal(t, 1, editDistance("title", "titles"))
+	assert.Equal(t, 5, editDistance("", "title"))
+	assert.Equal(t, 1, editDistance("descrição", "descricão"))
+}
+
+func Test_expectedString(t *testing.T) {
+	gotString, gotOK := expectedString(gomega.Equal("book.title"))
+	assert.Equal(t, "book.title", gotString)
+	assert.True(t, gotOK)
+
+	_, gotOK = expectedString(gomega.Equal(42))
+	assert.False(t, gotOK)
+
+	_, gotOK = expectedString(gomega.HavePrefix("book"))
+	assert.False(t, gotOK)
+}
@@ -0,0 +1,24 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HavePreconditionViolation matches a given type, subject and description (if informed) of any of the violations of
+// the errdetails.PreconditionFailure in the *status.Status details.
+func HavePreconditionViolation(violationType, subject string, description ...string) *matchersimpl.GRPCStatusMatcher {
+	return HavePreconditionViolationMatching(gomega.Equal(violationType), gomega.Equal(subject), optionalEqual(description))
+}
+
+// HavePreconditionViolationMatching works as HavePreconditionViolation, but the type, subject and description of the
+// violations are matched against the given matchers. A nil matcher matches any value.
+func HavePreconditionViolationMatching(violationType, subject, description types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchPreconditionFailure(&matchersimpl.GRPCPreconditionViolationMatcher{
+		Type:        violationType,
+		Subject:     subject,
+		Description: description,
+	})
+}
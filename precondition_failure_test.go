@@ -0,0 +1,55 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("PreconditionFailure", func() {
+	st, err := status.New(codes.FailedPrecondition, "random error").WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "TOS", Subject: "google.com/cloud", Description: "terms of service not accepted"},
+			{Type: "STATE", Subject: "orders/123", Description: "order is already shipped"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HavePreconditionViolation", func() {
+		It("should match a violation by type and subject", func() {
+			Expect(err).To(HavePreconditionViolation("STATE", "orders/123"))
+		})
+
+		It("should match a violation by type, subject and description", func() {
+			Expect(err).To(HavePreconditionViolation("TOS", "google.com/cloud", "terms of service not accepted"))
+		})
+
+		It("should not match a violation with a different type", func() {
+			Expect(err).ToNot(HavePreconditionViolation("TOS", "orders/123"))
+		})
+	})
+
+	Describe("HavePreconditionViolationMatching", func() {
+		It("should match a violation by matchers", func() {
+			Expect(err).To(HavePreconditionViolationMatching(Equal("STATE"), HavePrefix("orders/"), nil))
+		})
+
+		It("should not match when no violation matches all the matchers", func() {
+			Expect(err).ToNot(HavePreconditionViolationMatching(Equal("TOS"), HavePrefix("orders/"), nil))
+		})
+	})
+})
+
+func ExampleHavePreconditionViolation() {
+	st, err := status.New(codes.FailedPrecondition, "message").WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "STATE", Subject: "orders/123", Description: "order is already shipped"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HavePreconditionViolation("STATE", "orders/123"))
+}
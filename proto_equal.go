@@ -7,10 +7,120 @@ import (
 	"github.com/jamillosantos/gomega-grpc/matchersimpl"
 )
 
-// ProtoEqual is the matcher for comparing proto.Message values. This matcher is powered by the
-// proto.Equal function, provided by the default GRPC implementation.
-func ProtoEqual(m proto.Message) types.GomegaMatcher {
-	return &matchersimpl.ProtoEqualMatcher{
+// ProtoEqualOption customizes the comparison made by ProtoEqual.
+type ProtoEqualOption func(matcher *matchersimpl.ProtoEqualMatcher)
+
+// ProtoEqual is the matcher for comparing proto.Message values. Messages are walked field by field, with the same
+// semantics of proto.Equal, and the failure message lists each difference by its field path (eg:
+// `items[0].sku: "a" -> "b"`).
+//
+// The comparison can be relaxed with the ProtoEqualOption functions: IgnoringFields, IgnoringRepeatedOrder,
+// IgnoringRepeatedOrderOf, ApproximatingFloats, ApproximatingFloatsOf and EquatingNaNs.
+func ProtoEqual(m proto.Message, opts ...ProtoEqualOption) types.GomegaMatcher {
+	matcher := &matchersimpl.ProtoEqualMatcher{
 		Expected: m,
 	}
+	for _, opt := range opts {
+		opt(matcher)
+	}
+	return matcher
+}
+
+// IgnoringFields leaves the given field paths out of the comparison. A path is a list of field names separated by dots,
+// and `[*]` goes through all the elements of a repeated or map field (eg: `metadata.create_time` or `items[*].etag`).
+//
+// Paths are checked against the descriptor of the expected message, so a path that does not exist fails the match.
+func IgnoringFields(paths ...string) ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		matcher.IgnoredFields = append(matcher.IgnoredFields, paths...)
+	}
+}
+
+// IgnoringRepeatedOrder compares all the repeated fields, at any depth, as multisets. Repeated messages are compared
+// using the same rules of the ProtoEqual matcher.
+func IgnoringRepeatedOrder() ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		matcher.IgnoreRepeatedOrder = true
+	}
+}
+
+// IgnoringRepeatedOrderOf compares only the repeated fields at the given paths as multisets. See IgnoringFields for the
+// path format.
+func IgnoringRepeatedOrderOf(paths ...string) ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		matcher.UnorderedFields = append(matcher.UnorderedFields, paths...)
+	}
+}
+
+// ApproximatingFloats compares all the float and double fields, including wrappers and repeated values, with the given
+// tolerance. Two values are equal when their difference is within absolute, or within relative times the greatest of
+// their magnitudes.
+//
+// When comparing with a tolerance, NaN is not equal to NaN unless EquatingNaNs is also given.
+func ApproximatingFloats(absolute, relative float64) ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		matcher.FloatTolerance = &matchersimpl.FloatTolerance{Absolute: absolute, Relative: relative}
+	}
+}
+
+// ApproximatingFloatsOf works as ApproximatingFloats, but only for the float and double fields at, or under, the given
+// paths. It takes precedence over ApproximatingFloats. See IgnoringFields for the path format.
+func ApproximatingFloatsOf(absolute, relative float64, paths ...string) ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		if matcher.FieldFloatTolerances == nil {
+			matcher.FieldFloatTolerances = make(map[string]matchersimpl.FloatTolerance, len(paths))
+		}
+		for _, path := range paths {
+			matcher.FieldFloatTolerances[path] = matchersimpl.FloatTolerance{Absolute: absolute, Relative: relative}
+		}
+	}
+}
+
+// EquatingNaNs makes NaN equal to NaN when float and double fields are compared with a tolerance.
+func EquatingNaNs() ProtoEqualOption {
+	return func(matcher *matchersimpl.ProtoEqualMatcher) {
+		matcher.EquateNaNs = true
+	}
+}
+
+// ProtoEqualText works as ProtoEqual, but the expected message is written in the protobuf text format. The text is
+// parsed into a new message of the same type of template:
+//
+//	Expect(order).To(ProtoEqualText(&pb.Order{}, "id: 'x' items { sku: 'a' }"))
+//
+// If the text cannot be parsed, the match fails with an error pointing to the line and column of the problem.
+func ProtoEqualText(template proto.Message, text string, opts ...ProtoEqualOption) types.GomegaMatcher {
+	return newProtoLiteralEqual(template, text, matchersimpl.ProtoLiteralText, opts)
+}
+
+// ProtoEqualJSON works as ProtoEqualText, but the expected message is written in the protobuf JSON format.
+func ProtoEqualJSON(template proto.Message, json string, opts ...ProtoEqualOption) types.GomegaMatcher {
+	return newProtoLiteralEqual(template, json, matchersimpl.ProtoLiteralJSON, opts)
+}
+
+func newProtoLiteralEqual(template proto.Message, literal string, format matchersimpl.ProtoLiteralFormat, opts []ProtoEqualOption) types.GomegaMatcher {
+	matcher := &matchersimpl.ProtoLiteralEqualMatcher{
+		Template: template,
+		Literal:  literal,
+		Format:   format,
+	}
+	for _, opt := range opts {
+		opt(&matcher.ProtoEqualMatcher)
+	}
+	return matcher
+}
+
+// MatchProtoGolden works as ProtoEqual, but the expected message is stored in the golden file at path. Files with the
+// `.json` extension are in the protobuf JSON format, other extensions are in the protobuf text format.
+//
+// Running the tests with the GOMEGA_GRPC_UPDATE_GOLDEN=1 environment variable rewrites the golden files with the
+// actual messages.
+func MatchProtoGolden(path string, opts ...ProtoEqualOption) types.GomegaMatcher {
+	matcher := &matchersimpl.ProtoGoldenMatcher{
+		Path: path,
+	}
+	for _, opt := range opts {
+		opt(&matcher.ProtoEqualMatcher)
+	}
+	return matcher
 }
@@ -1,10 +1,149 @@
 package grpcmatchers
 
 import (
+	"math"
+
+	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var _ = Describe("ProtoEqual", func() {
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "field1", Description: "description1"},
+			{Field: "field2", Description: "description2"},
+		},
+	}
+
+	Describe("IgnoringFields", func() {
+		It("should match ignoring the given fields", func() {
+			Expect(badRequest).To(ProtoEqual(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "field1"},
+					{Field: "field2"},
+				},
+			}, IgnoringFields("field_violations[*].description")))
+		})
+
+		It("should not match when other fields are different", func() {
+			Expect(badRequest).ToNot(ProtoEqual(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "field1"},
+				},
+			}, IgnoringFields("field_violations[*].description")))
+		})
+
+		It("should fail with a field that does not exist", func() {
+			_, err := ProtoEqual(&errdetails.BadRequest{}, IgnoringFields("violations")).Match(badRequest)
+			Expect(err).To(MatchError(ContainSubstring(`has no field "violations"`)))
+		})
+	})
+
+	Describe("IgnoringRepeatedOrder", func() {
+		It("should match repeated fields in any order", func() {
+			Expect(badRequest).To(ProtoEqual(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "field2", Description: "description2"},
+					{Field: "field1", Description: "description1"},
+				},
+			}, IgnoringRepeatedOrder()))
+		})
+
+		It("should not match different elements", func() {
+			Expect(badRequest).ToNot(ProtoEqual(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "field2", Description: "description2"},
+					{Field: "field1", Description: "description2"},
+				},
+			}, IgnoringRepeatedOrder()))
+		})
+	})
+
+	Describe("IgnoringRepeatedOrderOf", func() {
+		It("should match the given repeated field in any order", func() {
+			Expect(badRequest).To(ProtoEqual(&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "field2", Description: "description2"},
+					{Field: "field1", Description: "description1"},
+				},
+			}, IgnoringRepeatedOrderOf("field_violations")))
+		})
+	})
+
+	Describe("ApproximatingFloats", func() {
+		coordinates := &structpb.ListValue{
+			Values: []*structpb.Value{structpb.NewNumberValue(-23.5505), structpb.NewNumberValue(-46.6333)},
+		}
+
+		It("should match floats within the tolerance", func() {
+			Expect(coordinates).To(ProtoEqual(&structpb.ListValue{
+				Values: []*structpb.Value{structpb.NewNumberValue(-23.55), structpb.NewNumberValue(-46.63)},
+			}, ApproximatingFloats(0.01, 0)))
+		})
+
+		It("should not match floats out of the tolerance", func() {
+			Expect(coordinates).ToNot(ProtoEqual(&structpb.ListValue{
+				Values: []*structpb.Value{structpb.NewNumberValue(-23.55), structpb.NewNumberValue(-46.63)},
+			}, ApproximatingFloats(0.001, 0)))
+		})
+
+		It("should match wrappers", func() {
+			Expect(wrapperspb.Double(0.1 + 0.2)).To(ProtoEqual(wrapperspb.Double(0.3), ApproximatingFloats(0, 1e-9)))
+		})
+
+		It("should prefer the tolerance of the field", func() {
+			Expect(coordinates).ToNot(ProtoEqual(&structpb.ListValue{
+				Values: []*structpb.Value{structpb.NewNumberValue(-23.55), structpb.NewNumberValue(-46.63)},
+			}, ApproximatingFloats(0.01, 0), ApproximatingFloatsOf(0, 0, "values")))
+		})
+
+		It("should match NaNs only when equating them", func() {
+			Expect(wrapperspb.Double(math.NaN())).ToNot(ProtoEqual(wrapperspb.Double(math.NaN()), ApproximatingFloats(0.1, 0)))
+			Expect(wrapperspb.Double(math.NaN())).To(ProtoEqual(wrapperspb.Double(math.NaN()), ApproximatingFloats(0.1, 0), EquatingNaNs()))
+		})
+	})
+
+	Describe("ProtoEqualText", func() {
+		It("should match a textproto literal", func() {
+			Expect(badRequest).To(ProtoEqualText(&errdetails.BadRequest{}, `
+				field_violations { field: "field1" description: "description1" }
+				field_violations { field: "field2" description: "description2" }
+			`))
+		})
+
+		It("should fail with an invalid literal", func() {
+			_, err := ProtoEqualText(&errdetails.BadRequest{}, "field_violations { fild: 'x' }").Match(badRequest)
+			Expect(err).To(MatchError(ContainSubstring("line 1:20")))
+		})
+	})
+
+	Describe("ProtoEqualJSON", func() {
+		It("should match a JSON literal with options", func() {
+			Expect(badRequest).To(ProtoEqualJSON(&errdetails.BadRequest{}, `{
+				"fieldViolations": [{"field": "field2"}, {"field": "field1"}]
+			}`, IgnoringFields("field_violations[*].description"), IgnoringRepeatedOrder()))
+		})
+	})
+
+	Describe("MatchProtoGolden", func() {
+		It("should match a golden file", func() {
+			Expect(&errdetails.ErrorInfo{
+				Reason: "some reason",
+				Domain: "some domain",
+			}).To(MatchProtoGolden("testdata/error_info.textproto"))
+		})
+
+		It("should not match a golden file", func() {
+			Expect(&errdetails.ErrorInfo{
+				Reason: "some reason",
+			}).ToNot(MatchProtoGolden("testdata/error_info.textproto"))
+		})
+	})
+})
+
 func ExampleProtoEqual() {
 	errInfo := &errdetails.ErrorInfo{
 		Reason: "some reason",
@@ -14,3 +153,61 @@ func ExampleProtoEqual() {
 		Reason: "some reason",
 	}))
 }
+
+func ExampleIgnoringFields() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "some reason",
+		Domain: "generated.domain",
+	}
+
+	Expect(errInfo).To(ProtoEqual(&errdetails.ErrorInfo{
+		Reason: "some reason",
+	}, IgnoringFields("domain")))
+}
+
+func ExampleIgnoringRepeatedOrder() {
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "field1"},
+			{Field: "field2"},
+		},
+	}
+
+	Expect(badRequest).To(ProtoEqual(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "field2"},
+			{Field: "field1"},
+		},
+	}, IgnoringRepeatedOrder()))
+}
+
+func ExampleApproximatingFloats() {
+	price := wrapperspb.Double(0.1 + 0.2)
+
+	Expect(price).To(ProtoEqual(wrapperspb.Double(0.3), ApproximatingFloats(0.000001, 0)))
+}
+
+func ExampleProtoEqualText() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "some reason",
+	}
+
+	Expect(errInfo).To(ProtoEqualText(&errdetails.ErrorInfo{}, `reason: "some reason"`))
+}
+
+func ExampleProtoEqualJSON() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "some reason",
+	}
+
+	Expect(errInfo).To(ProtoEqualJSON(&errdetails.ErrorInfo{}, `{"reason": "some reason"}`))
+}
+
+func ExampleMatchProtoGolden() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "some reason",
+		Domain: "some domain",
+	}
+
+	Expect(errInfo).To(MatchProtoGolden("testdata/error_info.textproto"))
+}
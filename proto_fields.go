@@ -0,0 +1,59 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// ProtoFields maps proto field names to the matchers applied to their values.
+type ProtoFields = matchersimpl.ProtoFields
+
+// MatchProtoFields matches a proto.Message by applying a matcher to each of the given fields, in the same fashion of
+// gstruct.MatchFields. Fields are named after the proto (or JSON) names and are resolved using the message descriptor.
+// Unknown field names, including the ones of nested MatchProtoFields, fail the match before any field is matched.
+//
+// The message type is only known when matching, so the field names are checked then. Use MatchProtoFieldsOf to check
+// them when the matcher is built.
+//
+// The gstruct.IgnoreExtras option ignores the populated fields that are not listed, and gstruct.IgnoreMissing skips
+// the matchers of the fields that are not populated.
+//
+//	Expect(order).To(MatchProtoFields(IgnoreExtras, ProtoFields{
+//		"name":   Equal("x"),
+//		"labels": HaveKey("env"),
+//		"spec": MatchProtoFields(IgnoreExtras, ProtoFields{
+//			"image": Equal("nginx"),
+//		}),
+//	}))
+func MatchProtoFields(options gstruct.Options, fields ProtoFields) types.GomegaMatcher {
+	return newProtoFieldsMatcher(options, fields)
+}
+
+// MatchProtoFieldsOf works as MatchProtoFields, but the field names are checked against the descriptor of the given
+// template message when the matcher is built. It panics on unknown field names (or a nil template), so typos and
+// fields removed from the proto are reported where the matcher is written:
+//
+//	Expect(order).To(MatchProtoFieldsOf(&pb.Order{}, IgnoreExtras, ProtoFields{
+//		"name": Equal("x"),
+//	}))
+func MatchProtoFieldsOf(template proto.Message, options gstruct.Options, fields ProtoFields) types.GomegaMatcher {
+	if template == nil {
+		panic("MatchProtoFieldsOf: the template message must not be nil")
+	}
+	matcher := newProtoFieldsMatcher(options, fields)
+	if err := matcher.Validate(template.ProtoReflect().Descriptor()); err != nil {
+		panic("MatchProtoFieldsOf: " + err.Error())
+	}
+	return matcher
+}
+
+func newProtoFieldsMatcher(options gstruct.Options, fields ProtoFields) *matchersimpl.ProtoFieldsMatcher {
+	return &matchersimpl.ProtoFieldsMatcher{
+		Fields:        fields,
+		IgnoreExtras:  options&gstruct.IgnoreExtras != 0,
+		IgnoreMissing: options&gstruct.IgnoreMissing != 0,
+	}
+}
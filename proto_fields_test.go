@@ -0,0 +1,101 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var _ = Describe("MatchProtoFields", func() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "reason",
+		Domain: "domain",
+		Metadata: map[string]string{
+			"key": "value",
+		},
+	}
+
+	It("should match all the fields", func() {
+		Expect(errInfo).To(MatchProtoFields(0, ProtoFields{
+			"reason":   Equal("reason"),
+			"domain":   HavePrefix("dom"),
+			"metadata": HaveKey("key"),
+		}))
+	})
+
+	It("should not match extra fields", func() {
+		Expect(errInfo).ToNot(MatchProtoFields(0, ProtoFields{
+			"reason": Equal("reason"),
+		}))
+	})
+
+	It("should ignore extra fields", func() {
+		Expect(errInfo).To(MatchProtoFields(IgnoreExtras, ProtoFields{
+			"reason": Equal("reason"),
+		}))
+	})
+
+	It("should fail with an unknown field", func() {
+		_, err := MatchProtoFields(IgnoreExtras, ProtoFields{
+			"reasn": Equal("reason"),
+		}).Match(errInfo)
+		Expect(err).To(MatchError(ContainSubstring("reasn")))
+	})
+
+	Describe("MatchProtoFieldsOf", func() {
+		It("should match the fields of the template message type", func() {
+			Expect(errInfo).To(MatchProtoFieldsOf(&errdetails.ErrorInfo{}, IgnoreExtras, ProtoFields{
+				"reason": Equal("reason"),
+			}))
+		})
+
+		It("should panic with an unknown field when built", func() {
+			Expect(func() {
+				MatchProtoFieldsOf(&errdetails.ErrorInfo{}, IgnoreExtras, ProtoFields{
+					"reasn": Equal("reason"),
+				})
+			}).To(PanicWith(ContainSubstring("reasn")))
+		})
+
+		It("should panic with an unknown nested field when built", func() {
+			Expect(func() {
+				MatchProtoFieldsOf(&descriptorpb.FileDescriptorProto{}, IgnoreExtras, ProtoFields{
+					"options": MatchProtoFields(IgnoreExtras, ProtoFields{
+						"go_pkg": Equal("x"),
+					}),
+				})
+			}).To(PanicWith(ContainSubstring("options.go_pkg")))
+		})
+
+		It("should panic with a nil template", func() {
+			Expect(func() {
+				MatchProtoFieldsOf(nil, IgnoreExtras, ProtoFields{})
+			}).To(Panic())
+		})
+	})
+
+	It("should match nested fields", func() {
+		Expect(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "field1", Description: "description1"},
+			},
+		}).To(MatchProtoFields(IgnoreExtras, ProtoFields{
+			"field_violations": ConsistOf(MatchProtoFields(IgnoreExtras, ProtoFields{
+				"field": Equal("field1"),
+			})),
+		}))
+	})
+})
+
+func ExampleMatchProtoFields() {
+	errInfo := &errdetails.ErrorInfo{
+		Reason: "some reason",
+		Domain: "some domain",
+	}
+
+	Expect(errInfo).To(MatchProtoFields(IgnoreExtras, ProtoFields{
+		"reason": Equal("some reason"),
+	}))
+}
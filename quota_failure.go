@@ -0,0 +1,32 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveQuotaViolation matches a given subject and description (if informed) of any of the violations of the
+// errdetails.QuotaFailure in the *status.Status details.
+func HaveQuotaViolation(subject string, description ...string) *matchersimpl.GRPCStatusMatcher {
+	return HaveQuotaViolationMatching(gomega.Equal(subject), optionalEqual(description))
+}
+
+// HaveQuotaViolationMatching works as HaveQuotaViolation, but the subject and description of the violations are
+// matched against the given matchers. A nil matcher matches any value.
+func HaveQuotaViolationMatching(subject, description types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchQuotaFailure(&matchersimpl.GRPCQuotaViolationMatcher{
+		Subject:     subject,
+		Description: description,
+	})
+}
+
+// optionalEqual returns an Equal matcher for the first of the given values, if it is informed and not empty.
+// Otherwise, it returns nil, which matches any value.
+func optionalEqual(values []string) types.GomegaMatcher {
+	if len(values) == 0 || values[0] == "" {
+		return nil
+	}
+	return gomega.Equal(values[0])
+}
@@ -0,0 +1,64 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("QuotaFailure", func() {
+	st, err := status.New(codes.ResourceExhausted, "random error").WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "clientip:127.0.0.1", Description: "daily limit exceeded"},
+			{Subject: "project:123", Description: "rate limit exceeded"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveQuotaViolation", func() {
+		It("should match a violation by subject", func() {
+			Expect(err).To(HaveQuotaViolation("project:123"))
+		})
+
+		It("should match a violation by subject and description", func() {
+			Expect(err).To(HaveQuotaViolation("clientip:127.0.0.1", "daily limit exceeded"))
+		})
+
+		It("should not match a violation with a different description", func() {
+			Expect(err).ToNot(HaveQuotaViolation("clientip:127.0.0.1", "rate limit exceeded"))
+		})
+
+		It("should not match a non existing subject", func() {
+			Expect(err).ToNot(HaveQuotaViolation("project:456"))
+		})
+	})
+
+	Describe("HaveQuotaViolationMatching", func() {
+		It("should match a violation by matchers", func() {
+			Expect(err).To(HaveQuotaViolationMatching(HavePrefix("project:"), ContainSubstring("rate")))
+		})
+
+		It("should match any value with a nil matcher", func() {
+			Expect(err).To(HaveQuotaViolationMatching(nil, ContainSubstring("daily")))
+		})
+
+		It("should not match when no violation matches all the matchers", func() {
+			Expect(err).ToNot(HaveQuotaViolationMatching(HavePrefix("project:"), ContainSubstring("daily")))
+		})
+	})
+})
+
+func ExampleHaveQuotaViolation() {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "clientip:127.0.0.1", Description: "daily limit exceeded"},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveQuotaViolation("clientip:127.0.0.1"))
+	Expect(st.Err()).To(HaveQuotaViolation("clientip:127.0.0.1", "daily limit exceeded"))
+}
@@ -0,0 +1,19 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveRequestID will match the *errdetails.RequestInfo RequestId property against the given matcher.
+func HaveRequestID(matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailItemsMatcher[*errdetails.RequestInfo, *errdetails.RequestInfo]{
+		Description: "a request info",
+		Items:       detailItself[*errdetails.RequestInfo],
+		Props: []matchersimpl.DetailProp[*errdetails.RequestInfo]{
+			{Name: "request_id", PropMap: func(r *errdetails.RequestInfo) interface{} { return r.GetRequestId() }, Matcher: matcher},
+		},
+	})
+}
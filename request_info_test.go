@@ -0,0 +1,40 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("RequestInfo", func() {
+	st, err := status.New(codes.Internal, "random error").WithDetails(&errdetails.RequestInfo{
+		RequestId:   "3c7e1f0a",
+		ServingData: "stack",
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveRequestID", func() {
+		It("should match the request id", func() {
+			Expect(err).To(HaveRequestID(Equal("3c7e1f0a")))
+		})
+
+		It("should not match a different request id", func() {
+			Expect(err).ToNot(HaveRequestID(Equal("b4d2")))
+		})
+
+		It("should fail when there is no request info", func() {
+			_, matchErr := HaveRequestID(Equal("3c7e1f0a")).Match(status.Error(codes.Internal, "random error"))
+			Expect(matchErr).To(HaveOccurred())
+		})
+	})
+})
+
+func ExampleHaveRequestID() {
+	st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.RequestInfo{RequestId: "3c7e1f0a"})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveRequestID(Not(BeEmpty())))
+}
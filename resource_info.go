@@ -0,0 +1,23 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveResourceInfo matches a given resource type and name of any of the errdetails.ResourceInfo in the
+// *status.Status details.
+//
+//	Expect(err).To(HaveResourceInfo("book", "shelves/1/books/2"))
+func HaveResourceInfo(resourceType, resourceName string) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailItemsMatcher[*errdetails.ResourceInfo, *errdetails.ResourceInfo]{
+		Description: "a resource info",
+		Items:       detailItself[*errdetails.ResourceInfo],
+		Props: []matchersimpl.DetailProp[*errdetails.ResourceInfo]{
+			{Name: "resource_type", PropMap: func(r *errdetails.ResourceInfo) interface{} { return r.GetResourceType() }, Matcher: gomega.Equal(resourceType)},
+			{Name: "resource_name", PropMap: func(r *errdetails.ResourceInfo) interface{} { return r.GetResourceName() }, Matcher: gomega.Equal(resourceName)},
+		},
+	})
+}
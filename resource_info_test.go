@@ -0,0 +1,43 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("ResourceInfo", func() {
+	st, err := status.New(codes.NotFound, "random error").WithDetails(
+		&errdetails.ResourceInfo{ResourceType: "book", ResourceName: "shelves/1/books/2"},
+		&errdetails.ResourceInfo{ResourceType: "shelf", ResourceName: "shelves/1"},
+	)
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveResourceInfo", func() {
+		It("should match a resource info", func() {
+			Expect(err).To(HaveResourceInfo("book", "shelves/1/books/2"))
+			Expect(err).To(HaveResourceInfo("shelf", "shelves/1"))
+		})
+
+		It("should not match properties of different resource infos", func() {
+			Expect(err).ToNot(HaveResourceInfo("book", "shelves/1"))
+		})
+
+		It("should list the details present on failures", func() {
+			Expect(HaveResourceInfo("author", "authors/1").FailureMessage(err)).To(ContainSubstring(`type.googleapis.com/google.rpc.ResourceInfo: {"resourceType":"book","resourceName":"shelves/1/books/2"}`))
+		})
+	})
+})
+
+func ExampleHaveResourceInfo() {
+	st, err := status.New(codes.NotFound, "message").WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "book",
+		ResourceName: "shelves/1/books/2",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveResourceInfo("book", "shelves/1/books/2"))
+}
@@ -0,0 +1,31 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveRetryDelay will match the *errdetails.RetryInfo RetryDelay property, converted to a time.Duration, against the
+// given matcher.
+//
+//	Expect(err).To(HaveRetryDelay(BeNumerically("~", 2*time.Second, 100*time.Millisecond)))
+func HaveRetryDelay(matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchRetryInfo(&matchersimpl.GRPCStatusDetailPropMatcher[*errdetails.RetryInfo]{
+		PropMap: func(retryInfo *errdetails.RetryInfo) interface{} {
+			return retryInfo.GetRetryDelay().AsDuration()
+		},
+		Matcher: matcher,
+	})
+}
+
+// HaveRetryInfo will match a *status.Status that has an *errdetails.RetryInfo in the details and one of the given
+// codes. If no code is given, matchersimpl.DefaultRetryableCodes are allowed to carry a RetryInfo: Unavailable,
+// ResourceExhausted and Aborted.
+func HaveRetryInfo(allowedCodes ...codes.Code) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCRetryInfoCodesMatcher{
+		AllowedCodes: allowedCodes,
+	})
+}
@@ -0,0 +1,63 @@
+package grpcmatchers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("RetryInfo", func() {
+	st, err := status.New(codes.Unavailable, "random error").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(2*time.Second + 50*time.Millisecond),
+	})
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveRetryDelay", func() {
+		It("should match a retry delay within a tolerance", func() {
+			Expect(err).To(HaveRetryDelay(BeNumerically("~", 2*time.Second, 100*time.Millisecond)))
+		})
+
+		It("should not match a retry delay out of the tolerance", func() {
+			Expect(err).ToNot(HaveRetryDelay(BeNumerically("~", time.Second, 100*time.Millisecond)))
+		})
+	})
+
+	Describe("HaveRetryInfo", func() {
+		It("should match a RetryInfo with an allowed code", func() {
+			Expect(err).To(HaveRetryInfo())
+			Expect(err).To(HaveRetryInfo(codes.Unavailable))
+		})
+
+		It("should not match a RetryInfo with a code that is not allowed", func() {
+			Expect(err).ToNot(HaveRetryInfo(codes.ResourceExhausted))
+		})
+
+		It("should not match a status without RetryInfo", func() {
+			Expect(status.New(codes.Unavailable, "random error").Err()).ToNot(HaveRetryInfo())
+		})
+	})
+})
+
+func ExampleHaveRetryDelay() {
+	st, err := status.New(codes.Unavailable, "message").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(2 * time.Second),
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveRetryDelay(BeNumerically("~", 2*time.Second, 100*time.Millisecond)))
+}
+
+func ExampleHaveRetryInfo() {
+	st, err := status.New(codes.ResourceExhausted, "message").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Second),
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveRetryInfo(codes.Unavailable, codes.ResourceExhausted))
+}
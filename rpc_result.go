@@ -0,0 +1,37 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// RPCResult holds the response and the error returned by a unary RPC. See Result.
+type RPCResult = matchersimpl.RPCResult
+
+// Result wraps the values returned by a unary RPC, so SucceedWith and FailWith can match both at once. As Go forwards
+// multiple return values to a function call, the RPC can be given directly:
+//
+//	Expect(Result(client.GetBook(ctx, req))).To(FailWith(HaveStatusCode(Equal(codes.NotFound))))
+//
+// Gomega requires the extra values given to Expect and returned by the functions polled by Eventually to be nil, so
+// the error would not reach the matchers without it:
+//
+//	Eventually(func() RPCResult {
+//		return Result(client.GetBook(ctx, req))
+//	}).Should(SucceedWith(ProtoEqual(book)))
+func Result(response interface{}, err error) RPCResult {
+	return RPCResult{Response: response, Err: err}
+}
+
+// SucceedWith matches a RPCResult without error whose response is matched by the given matcher. A nil matcher only
+// checks that the RPC succeeded.
+func SucceedWith(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchersimpl.GRPCSucceedWithMatcher{Matcher: matcher}
+}
+
+// FailWith matches a RPCResult with a nil response whose error is matched by the given matcher, usually one of the
+// status matchers. A nil matcher only checks that the RPC failed.
+func FailWith(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchersimpl.GRPCFailWithMatcher{Matcher: matcher}
+}
@@ -0,0 +1,69 @@
+package grpcmatchers
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getReason stands for a generated client method, returning a typed nil response on failures.
+func getReason(_ context.Context, reason string) (*errdetails.ErrorInfo, error) {
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty reason")
+	}
+	return &errdetails.ErrorInfo{Reason: reason}, nil
+}
+
+var _ = Describe("RPCResult", func() {
+	ctx := context.Background()
+
+	Describe("SucceedWith", func() {
+		It("should match the response of a successful RPC", func() {
+			Expect(Result(getReason(ctx, "BOOK_NOT_FOUND"))).To(SucceedWith(ProtoEqual(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})))
+			Expect(Result(getReason(ctx, "BOOK_NOT_FOUND"))).ToNot(SucceedWith(ProtoEqual(&errdetails.ErrorInfo{Reason: "OTHER"})))
+		})
+
+		It("should not match a failed RPC", func() {
+			Expect(Result(getReason(ctx, ""))).ToNot(SucceedWith(nil))
+		})
+	})
+
+	Describe("FailWith", func() {
+		It("should match the error of a failed RPC", func() {
+			Expect(Result(getReason(ctx, ""))).To(FailWith(HaveStatusCode(Equal(codes.InvalidArgument))))
+			Expect(Result(getReason(ctx, ""))).ToNot(FailWith(HaveStatusCode(Equal(codes.NotFound))))
+		})
+
+		It("should not match a successful RPC", func() {
+			Expect(Result(getReason(ctx, "BOOK_NOT_FOUND"))).ToNot(FailWith(nil))
+		})
+
+		It("should not match a failed RPC with a response", func() {
+			Expect(Result(&errdetails.ErrorInfo{}, status.Error(codes.Internal, "internal"))).ToNot(FailWith(nil))
+		})
+	})
+
+	It("should poll an RPC until it reaches the expected state", func() {
+		var calls int32
+		Eventually(func() RPCResult {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return Result(getReason(ctx, ""))
+			}
+			return Result(getReason(ctx, "BOOK_NOT_FOUND"))
+		}, time.Second, time.Millisecond).Should(SucceedWith(ProtoEqual(&errdetails.ErrorInfo{Reason: "BOOK_NOT_FOUND"})))
+	})
+})
+
+func ExampleFailWith() {
+	getBook := func(context.Context, string) (*errdetails.ResourceInfo, error) {
+		return nil, status.Error(codes.NotFound, "book not found")
+	}
+
+	Expect(Result(getBook(context.Background(), "books/1"))).To(FailWith(HaveStatusCode(Equal(codes.NotFound))))
+}
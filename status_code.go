@@ -0,0 +1,70 @@
+package grpcmatchers
+
+import (
+	"google.golang.org/grpc/codes"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveStatusCodeIn will match a *status.Status whose code is one of the given codes.
+//
+//	Expect(err).To(HaveStatusCodeIn(codes.Unavailable, codes.ResourceExhausted))
+func HaveStatusCodeIn(expected ...codes.Code) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusCodeInMatcher{
+		Codes: expected,
+	})
+}
+
+// HaveStatusCodeNamed will match a *status.Status whose code is one of the given names, in the proto (NOT_FOUND) or
+// Go (NotFound) spelling. Unknown names fail the match with an error.
+//
+//	Expect(err).To(HaveStatusCodeNamed("NOT_FOUND"))
+func HaveStatusCodeNamed(names ...string) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusCodeInMatcher{
+		Names: names,
+	})
+}
+
+// BeRetryableStatus will match a *status.Status whose code is retryable. If no codes are given,
+// matchersimpl.DefaultRetryableCodes are used: Unavailable, ResourceExhausted and Aborted.
+func BeRetryableStatus(retryableCodes ...codes.Code) *matchersimpl.GRPCStatusMatcher {
+	if len(retryableCodes) == 0 {
+		retryableCodes = matchersimpl.DefaultRetryableCodes
+	}
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusCodeInMatcher{
+		Codes:       retryableCodes,
+		Description: "a retryable code",
+	})
+}
+
+// BeClientError will match a *status.Status whose code is caused by the client, the ones mapped to HTTP 4xx statuses
+// by grpc-gateway (eg: InvalidArgument, NotFound and PermissionDenied).
+func BeClientError() *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusCodeInMatcher{
+		Codes: matchersimpl.CodesByHTTPStatus(func(httpStatus int) bool {
+			return httpStatus >= 400 && httpStatus < 500
+		}),
+		Description: "a client error code (HTTP 4xx)",
+	})
+}
+
+// BeServerError will match a *status.Status whose code is caused by the server, the ones mapped to HTTP 5xx statuses
+// by grpc-gateway (eg: Internal, Unavailable and Unimplemented).
+func BeServerError() *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusCodeInMatcher{
+		Codes: matchersimpl.CodesByHTTPStatus(func(httpStatus int) bool {
+			return httpStatus >= 500
+		}),
+		Description: "a server error code (HTTP 5xx)",
+	})
+}
+
+// HaveHTTPEquivalent will match a *status.Status whose code is mapped to the given HTTP status, using the grpc-gateway
+// mapping.
+//
+//	Expect(err).To(HaveHTTPEquivalent(http.StatusNotFound))
+func HaveHTTPEquivalent(httpStatus int) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCHTTPStatusMatcher{
+		Expected: httpStatus,
+	})
+}
@@ -0,0 +1,101 @@
+package grpcmatchers
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("StatusCode", func() {
+	notFound := status.Error(codes.NotFound, "random error")
+	unavailable := status.Error(codes.Unavailable, "random error")
+
+	Describe("HaveStatusCodeIn", func() {
+		It("should match one of the codes", func() {
+			Expect(unavailable).To(HaveStatusCodeIn(codes.Unavailable, codes.ResourceExhausted))
+		})
+
+		It("should not match other codes", func() {
+			Expect(notFound).ToNot(HaveStatusCodeIn(codes.Unavailable, codes.ResourceExhausted))
+		})
+
+		It("should list the codes on failures", func() {
+			Expect(HaveStatusCodeIn(codes.Unavailable, codes.Aborted).FailureMessage(notFound)).To(ContainSubstring("to be one of Unavailable (14), Aborted (10)"))
+		})
+	})
+
+	Describe("HaveStatusCodeNamed", func() {
+		It("should match a code by its name", func() {
+			Expect(notFound).To(HaveStatusCodeNamed("NOT_FOUND"))
+			Expect(notFound).To(HaveStatusCodeNamed("NotFound"))
+		})
+
+		It("should not match other codes", func() {
+			Expect(notFound).ToNot(HaveStatusCodeNamed("UNAVAILABLE", "INTERNAL"))
+		})
+
+		It("should fail with an unknown name", func() {
+			_, err := HaveStatusCodeNamed("NOT_FUOND").Match(notFound)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("BeRetryableStatus", func() {
+		It("should match the default retryable codes", func() {
+			Expect(unavailable).To(BeRetryableStatus())
+			Expect(status.Error(codes.Aborted, "random error")).To(BeRetryableStatus())
+			Expect(notFound).ToNot(BeRetryableStatus())
+		})
+
+		It("should match the given codes", func() {
+			Expect(status.Error(codes.DeadlineExceeded, "random error")).To(BeRetryableStatus(codes.DeadlineExceeded, codes.Unavailable))
+			Expect(status.Error(codes.Aborted, "random error")).ToNot(BeRetryableStatus(codes.DeadlineExceeded, codes.Unavailable))
+		})
+	})
+
+	Describe("BeClientError", func() {
+		It("should match the client errors", func() {
+			Expect(notFound).To(BeClientError())
+			Expect(status.Error(codes.InvalidArgument, "random error")).To(BeClientError())
+			Expect(unavailable).ToNot(BeClientError())
+			Expect(status.New(codes.OK, "")).ToNot(BeClientError())
+		})
+	})
+
+	Describe("BeServerError", func() {
+		It("should match the server errors", func() {
+			Expect(unavailable).To(BeServerError())
+			Expect(status.Error(codes.Internal, "random error")).To(BeServerError())
+			Expect(notFound).ToNot(BeServerError())
+		})
+	})
+
+	Describe("HaveHTTPEquivalent", func() {
+		It("should match the HTTP equivalent", func() {
+			Expect(notFound).To(HaveHTTPEquivalent(http.StatusNotFound))
+			Expect(status.Error(codes.ResourceExhausted, "random error")).To(HaveHTTPEquivalent(http.StatusTooManyRequests))
+		})
+
+		It("should not match a different HTTP status", func() {
+			Expect(unavailable).ToNot(HaveHTTPEquivalent(http.StatusNotFound))
+		})
+	})
+})
+
+func ExampleHaveStatusCodeIn() {
+	err := status.Error(codes.Unavailable, "message")
+
+	Expect(err).To(HaveStatusCodeIn(codes.Unavailable, codes.ResourceExhausted))
+	Expect(err).To(HaveStatusCodeNamed("UNAVAILABLE"))
+}
+
+func ExampleBeRetryableStatus() {
+	err := status.Error(codes.Unavailable, "message")
+
+	Expect(err).To(BeRetryableStatus())
+	Expect(err).To(BeServerError())
+	Expect(err).To(HaveHTTPEquivalent(http.StatusServiceUnavailable))
+}
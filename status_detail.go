@@ -0,0 +1,25 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveStatusDetail will find the first detail of type T in the *status.Status details and match it against the given
+// matcher. Any message type can be used, including custom details:
+//
+//	Expect(err).To(HaveStatusDetail[*errdetails.ErrorInfo](ProtoEqual(&errdetails.ErrorInfo{Reason: "reason"})))
+//	Expect(err).To(HaveStatusDetail[*pb.OrderConflict](MatchProtoFields(IgnoreExtras, ProtoFields{
+//		"order_id": Equal("x"),
+//	})))
+func HaveStatusDetail[T proto.Message](matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCMatchStatusDetail[T](matcher)
+}
+
+// detailItself is used as the Items of a matchersimpl.GRPCStatusDetailItemsMatcher when the detail itself is the item
+// being matched.
+func detailItself[T proto.Message](detail T) []T {
+	return []T{detail}
+}
@@ -0,0 +1,57 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var _ = Describe("HaveStatusDetail", func() {
+	custom, err := structpb.NewStruct(map[string]interface{}{
+		"order_id": "order1",
+	})
+	Expect(err).ToNot(HaveOccurred())
+	st, err := status.New(codes.FailedPrecondition, "random error").WithDetails(&errdetails.ErrorInfo{
+		Reason: "reason",
+	}, custom)
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	It("should match a detail with ProtoEqual", func() {
+		Expect(err).To(HaveStatusDetail[*errdetails.ErrorInfo](ProtoEqual(&errdetails.ErrorInfo{
+			Reason: "reason",
+		})))
+	})
+
+	It("should not match a different detail", func() {
+		Expect(err).ToNot(HaveStatusDetail[*errdetails.ErrorInfo](ProtoEqual(&errdetails.ErrorInfo{
+			Reason: "other reason",
+		})))
+	})
+
+	It("should match a custom detail with MatchProtoFields", func() {
+		Expect(err).To(HaveStatusDetail[*structpb.Struct](MatchProtoFields(IgnoreExtras, ProtoFields{
+			"fields": HaveKey("order_id"),
+		})))
+	})
+
+	It("should fail when the detail is not found", func() {
+		_, matchErr := HaveStatusDetail[*errdetails.BadRequest](Not(BeNil())).Match(err)
+		Expect(matchErr).To(MatchError(ContainSubstring("no *errdetails.BadRequest found in the details")))
+	})
+})
+
+func ExampleHaveStatusDetail() {
+	st, err := status.New(codes.Internal, "message").WithDetails(&errdetails.RequestInfo{
+		RequestId: "request id",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveStatusDetail[*errdetails.RequestInfo](ProtoEqual(&errdetails.RequestInfo{
+		RequestId: "request id",
+	})))
+}
@@ -0,0 +1,46 @@
+package grpcmatchers
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// HaveStatusDetails will match all the *status.Status details, decoded as a []proto.Message, against the given
+// collection matcher. Details that cannot be decoded fail the match instead of being compared.
+//
+//	Expect(err).To(HaveStatusDetails(ConsistOfProtos(&errdetails.ErrorInfo{Reason: "INVALID_BOOK"}, badRequest)))
+//	Expect(err).To(HaveStatusDetails(Not(ContainElement(BeAssignableToTypeOf(&errdetails.DebugInfo{})))))
+func HaveStatusDetails(matcher types.GomegaMatcher) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusDetailsMatcher{
+		Matcher: matcher,
+	})
+}
+
+// ConsistOfProtos works as ConsistOf, but the proto.Message elements are compared using ProtoEqual. Matchers can be
+// given as elements too.
+func ConsistOfProtos(elements ...interface{}) types.GomegaMatcher {
+	return gomega.ConsistOf(protoElementMatchers(elements)...)
+}
+
+// ContainProto works as ContainElement, but a proto.Message element is compared using ProtoEqual. A matcher can be
+// given as the element too.
+func ContainProto(element interface{}) types.GomegaMatcher {
+	return gomega.ContainElement(protoElementMatchers([]interface{}{element})[0])
+}
+
+// protoElementMatchers replaces the proto.Message elements by ProtoEqual matchers. Other elements are kept as they
+// are.
+func protoElementMatchers(elements []interface{}) []interface{} {
+	matchers := make([]interface{}, len(elements))
+	for i, element := range elements {
+		if m, ok := element.(proto.Message); ok {
+			matchers[i] = ProtoEqual(m)
+			continue
+		}
+		matchers[i] = element
+	}
+	return matchers
+}
@@ -0,0 +1,78 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var _ = Describe("StatusDetails", func() {
+	errorInfo := &errdetails.ErrorInfo{Reason: "INVALID_BOOK", Domain: "library.example.com"}
+	badRequest := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "book.title", Description: "must not be empty"},
+	}}
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(errorInfo, badRequest)
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("HaveStatusDetails", func() {
+		It("should match exactly the details", func() {
+			Expect(err).To(HaveStatusDetails(ConsistOfProtos(badRequest, errorInfo)))
+			Expect(err).To(HaveStatusDetails(HaveLen(2)))
+		})
+
+		It("should not match when there are other details", func() {
+			Expect(err).ToNot(HaveStatusDetails(ConsistOfProtos(errorInfo)))
+		})
+
+		It("should match a detail type that is not present", func() {
+			Expect(err).To(HaveStatusDetails(Not(ContainElement(BeAssignableToTypeOf(&errdetails.DebugInfo{})))))
+		})
+
+		It("should match a status without details", func() {
+			Expect(status.Error(codes.NotFound, "not found")).To(HaveStatusDetails(BeEmpty()))
+		})
+
+		It("should fail on undecodable details", func() {
+			withUnknown := status.FromProto(&spb.Status{
+				Code:    int32(codes.Internal),
+				Details: []*anypb.Any{{TypeUrl: "type.googleapis.com/unknown.Detail"}},
+			})
+			_, matchErr := HaveStatusDetails(BeEmpty()).Match(withUnknown)
+			Expect(matchErr).To(MatchError(ContainSubstring("details[0] type.googleapis.com/unknown.Detail")))
+		})
+	})
+
+	Describe("ContainProto", func() {
+		It("should match a detail", func() {
+			Expect(err).To(HaveStatusDetails(ContainProto(errorInfo)))
+		})
+
+		It("should not match a different detail", func() {
+			Expect(err).ToNot(HaveStatusDetails(ContainProto(&errdetails.ErrorInfo{Reason: "INVALID_AUTHOR"})))
+		})
+
+		It("should accept a matcher", func() {
+			Expect(err).To(HaveStatusDetails(ContainProto(MatchProtoFields(gstruct.IgnoreExtras, ProtoFields{
+				"reason": Equal("INVALID_BOOK"),
+			}))))
+		})
+	})
+})
+
+func ExampleHaveStatusDetails() {
+	errorInfo := &errdetails.ErrorInfo{Reason: "INVALID_BOOK"}
+	badRequest := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "book.title", Description: "must not be empty"},
+	}}
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(errorInfo, badRequest)
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(HaveStatusDetails(ConsistOfProtos(errorInfo, badRequest)))
+	Expect(st.Err()).To(HaveStatusDetails(Not(ContainElement(BeAssignableToTypeOf(&errdetails.DebugInfo{})))))
+}
@@ -0,0 +1,22 @@
+package grpcmatchers
+
+import (
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// StatusFields holds the matchers for the code, message and details of a *status.Status. A nil matcher skips the part.
+type StatusFields = matchersimpl.StatusFields
+
+// MatchStatus matches the code, message and details of a *status.Status in a single assertion, reporting all the parts
+// that did not match. Details are given to the matcher as a []proto.Message, decoded from the status:
+//
+//	Expect(err).To(MatchStatus(StatusFields{
+//		Code:    Equal(codes.InvalidArgument),
+//		Message: ContainSubstring("invalid book"),
+//		Details: ConsistOf(ProtoEqual(&errdetails.ErrorInfo{Reason: "INVALID_BOOK"})),
+//	}))
+func MatchStatus(fields StatusFields) *matchersimpl.GRPCStatusMatcher {
+	return matchersimpl.NewGRPCStatusMatcher(&matchersimpl.GRPCStatusFieldsMatcher{
+		Fields: fields,
+	})
+}
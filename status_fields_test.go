@@ -0,0 +1,66 @@
+package grpcmatchers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("StatusFields", func() {
+	errorInfo := &errdetails.ErrorInfo{Reason: "INVALID_BOOK", Domain: "library.example.com"}
+	badRequest := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "book.title", Description: "must not be empty"},
+	}}
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(errorInfo, badRequest)
+	Expect(err).ToNot(HaveOccurred())
+	err = st.Err()
+
+	Describe("MatchStatus", func() {
+		It("should match all the fields", func() {
+			Expect(err).To(MatchStatus(StatusFields{
+				Code:    Equal(codes.InvalidArgument),
+				Message: ContainSubstring("invalid"),
+				Details: ConsistOf(ProtoEqual(badRequest), ProtoEqual(errorInfo)),
+			}))
+		})
+
+		It("should skip the fields without matchers", func() {
+			Expect(err).To(MatchStatus(StatusFields{Code: Equal(codes.InvalidArgument)}))
+		})
+
+		It("should not match when any of the fields does not match", func() {
+			Expect(err).ToNot(MatchStatus(StatusFields{
+				Code:    Equal(codes.InvalidArgument),
+				Message: Equal("invalid author"),
+			}))
+		})
+
+		It("should report all the mismatched fields", func() {
+			matcher := MatchStatus(StatusFields{
+				Code:    Equal(codes.NotFound),
+				Message: Equal("invalid author"),
+				Details: HaveLen(1),
+			})
+			Expect(matcher.Match(err)).To(BeFalse())
+			message := matcher.FailureMessage(err)
+			Expect(message).To(ContainSubstring("code:"))
+			Expect(message).To(ContainSubstring("message:"))
+			Expect(message).To(ContainSubstring("details:"))
+		})
+	})
+})
+
+func ExampleMatchStatus() {
+	st, err := status.New(codes.InvalidArgument, "invalid book").WithDetails(&errdetails.ErrorInfo{
+		Reason: "INVALID_BOOK",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(st.Err()).To(MatchStatus(StatusFields{
+		Code:    Equal(codes.InvalidArgument),
+		Message: ContainSubstring("invalid"),
+		Details: ConsistOf(ProtoEqual(&errdetails.ErrorInfo{Reason: "INVALID_BOOK"})),
+	}))
+}
@@ -1,6 +1,9 @@
 package grpcmatchers
 
 import (
+	"errors"
+	"fmt"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -31,6 +34,40 @@ var _ = Describe("ErrorInfo", func() {
 			Expect(err).ToNot(HaveStatusMessage(Equal("not random error")))
 		})
 	})
+
+	Describe("actual values", func() {
+		It("should accept a *status.Status", func() {
+			Expect(st).To(HaveStatusCode(Equal(codes.NotFound)))
+		})
+
+		It("should accept a *spb.Status", func() {
+			Expect(st.Proto()).To(HaveStatusMessage(Equal("random error")))
+		})
+
+		It("should accept an error wrapping a grpc error", func() {
+			Expect(fmt.Errorf("fetching book: %w", err)).To(HaveStatusCode(Equal(codes.NotFound)))
+		})
+
+		It("should reject other values naming what was received", func() {
+			matcher := HaveStatusCode(Equal(codes.NotFound))
+			_, matchErr := matcher.Match(errors.New("random error"))
+			Expect(matchErr).To(HaveOccurred())
+			Expect(matcher.FailureMessage(errors.New("random error"))).To(ContainSubstring("is not a grpc error and does not wrap one"))
+			Expect(matcher.FailureMessage("random error")).To(ContainSubstring("<string>: random error"))
+		})
+
+		It("should render both codes with their names and numbers", func() {
+			err := status.Error(codes.InvalidArgument, "invalid book")
+			Expect(HaveStatusCode(Equal(codes.NotFound)).FailureMessage(err)).To(SatisfyAll(
+				ContainSubstring("InvalidArgument (3)\nto equal\n"),
+				ContainSubstring("NotFound (5)"),
+				Not(ContainSubstring("<codes.Code>")),
+			))
+			Expect(HaveStatusCode(Equal(codes.InvalidArgument)).NegatedFailureMessage(err)).To(
+				ContainSubstring("InvalidArgument (3)\nnot to equal\n"),
+			)
+		})
+	})
 })
 
 func ExampleHaveStatusCode() {
@@ -45,3 +82,9 @@ func ExampleHaveStatusMessage() {
 	Expect(err).To(HaveStatusMessage(Equal("this is a message")))
 	Expect(err).To(HaveStatusMessage(ContainSubstring("message")))
 }
+
+func ExampleHaveStatusCode_wrapped() {
+	err := fmt.Errorf("fetching book: %w", status.New(codes.NotFound, "book not found").Err())
+
+	Expect(err).To(HaveStatusCode(Equal(codes.NotFound)))
+}
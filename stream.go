@@ -0,0 +1,107 @@
+package grpcmatchers
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/jamillosantos/gomega-grpc/matchersimpl"
+)
+
+// StreamResult holds the messages received from a stream and the status it ended with. See DrainStream.
+//
+// The stream matchers accept the generated client streams (eg: pb.Books_ListBooksClient), any value with a
+// `Recv() (T, error)` method, the Recv function itself, a *CancelableStream or a *StreamResult. Streams are drained
+// until they end, so checking the same stream twice requires ReceiveWithin or DrainStream.
+type StreamResult = matchersimpl.StreamResult
+
+// CancelableStream is a stream paired with the function that cancels its context. See CancelOnTimeout.
+type CancelableStream = matchersimpl.CancelableStream
+
+// CancelOnTimeout pairs the stream with the cancel function of the context given to the RPC. When the stream matchers,
+// DrainStream or Converse time out, they call cancel and wait for the goroutine receiving from the stream to stop,
+// instead of leaving it blocked on Recv until the end of the test:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	stream, err := client.ListBooks(ctx, req)
+//	Expect(err).ToNot(HaveOccurred())
+//	Expect(CancelOnTimeout(stream, cancel)).To(EndWithStatus(HaveStatusCode(Equal(codes.OK))))
+func CancelOnTimeout(stream interface{}, cancel context.CancelFunc) *CancelableStream {
+	return &CancelableStream{
+		Stream: stream,
+		Cancel: cancel,
+	}
+}
+
+// StreamTimeout bounds how long the stream matchers wait for a stream to end, so a hung server fails the test instead
+// of hanging the suite. It is read when the matchers are created and defaults to 5 seconds. Use ReceiveWithin for a
+// different timeout on a single assertion.
+var StreamTimeout = 5 * time.Second
+
+// DrainStream receives all the messages of the stream until it ends, or StreamTimeout elapses, so several assertions
+// can be made on the same stream:
+//
+//	result, err := DrainStream(stream)
+//	Expect(err).ToNot(HaveOccurred())
+//	Expect(result).To(ReceiveProtosInOrder(bookA, bookB))
+//	Expect(result).To(EndWithStatus(HaveStatusCode(Equal(codes.OK))))
+//
+// Wrap the stream with CancelOnTimeout to stop receiving from it when StreamTimeout elapses.
+func DrainStream(stream interface{}) (*StreamResult, error) {
+	return matchersimpl.DrainStream(stream, StreamTimeout)
+}
+
+// ReceiveProtosInOrder drains the stream and succeeds if it received exactly the given elements, in order. The
+// proto.Message elements are compared using ProtoEqual. Matchers can be given as elements too:
+//
+//	Expect(stream).To(ReceiveProtosInOrder(bookA, ProtoEqual(bookB, IgnoringFields("update_time"))))
+func ReceiveProtosInOrder(elements ...interface{}) types.GomegaMatcher {
+	return newStreamMessagesMatcher(gomega.HaveExactElements(protoElementMatchers(elements)...))
+}
+
+// ReceiveProtosConsistingOf drains the stream and succeeds if it received exactly the given elements, in any order.
+// The proto.Message elements are compared using ProtoEqual. Matchers can be given as elements too.
+func ReceiveProtosConsistingOf(elements ...interface{}) types.GomegaMatcher {
+	return newStreamMessagesMatcher(gomega.ConsistOf(protoElementMatchers(elements)...))
+}
+
+func newStreamMessagesMatcher(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchersimpl.GRPCStreamMatcher{
+		Timeout: StreamTimeout,
+		Part: func(result *StreamResult) interface{} {
+			return result.Messages
+		},
+		Matcher: matcher,
+	}
+}
+
+// EndWithStatus drains the stream and matches the status it ended with against the given matcher, usually one of the
+// status matchers. Streams ended by io.EOF have the OK status:
+//
+//	Expect(stream).To(EndWithStatus(HaveStatusCode(Equal(codes.OK))))
+func EndWithStatus(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchersimpl.GRPCStreamMatcher{
+		Timeout: StreamTimeout,
+		Part: func(result *StreamResult) interface{} {
+			return result.Status
+		},
+		Matcher: matcher,
+	}
+}
+
+// ReceiveWithin drains the stream and succeeds if it ends within the timeout. Then, the drained stream is given to all
+// the matchers, so the same stream can be checked by several stream matchers:
+//
+//	Expect(stream).To(ReceiveWithin(time.Second,
+//		ReceiveProtosInOrder(bookA, bookB),
+//		EndWithStatus(HaveStatusCode(Equal(codes.OK))),
+//	))
+func ReceiveWithin(timeout time.Duration, matchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return &matchersimpl.GRPCStreamEndMatcher{
+		Timeout:  timeout,
+		Matchers: matchers,
+	}
+}
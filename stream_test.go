@@ -0,0 +1,128 @@
+package grpcmatchers
+
+import (
+	"context"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reasonsStream stands for a generated client stream, receiving an ErrorInfo per reason and then the err. A nil err
+// blocks the stream until the ctx is canceled, or forever if there is no ctx.
+type reasonsStream struct {
+	reasons []string
+	err     error
+	ctx     context.Context
+}
+
+func (s *reasonsStream) Recv() (*errdetails.ErrorInfo, error) {
+	if len(s.reasons) > 0 {
+		reason := s.reasons[0]
+		s.reasons = s.reasons[1:]
+		return &errdetails.ErrorInfo{Reason: reason}, nil
+	}
+	if s.err == nil && s.ctx != nil {
+		<-s.ctx.Done()
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	}
+	if s.err == nil {
+		select {}
+	}
+	return nil, s.err
+}
+
+var _ = Describe("Stream", func() {
+	a, b := &errdetails.ErrorInfo{Reason: "A"}, &errdetails.ErrorInfo{Reason: "B"}
+
+	Describe("ReceiveProtosInOrder", func() {
+		It("should match the messages in order", func() {
+			Expect(&reasonsStream{reasons: []string{"A", "B"}, err: io.EOF}).To(ReceiveProtosInOrder(a, ProtoEqual(b)))
+			Expect(&reasonsStream{reasons: []string{"B", "A"}, err: io.EOF}).ToNot(ReceiveProtosInOrder(a, b))
+			Expect(&reasonsStream{reasons: []string{"A"}, err: io.EOF}).ToNot(ReceiveProtosInOrder(a, b))
+		})
+
+		It("should accept the Recv function", func() {
+			stream := &reasonsStream{reasons: []string{"A"}, err: io.EOF}
+			Expect(stream.Recv).To(ReceiveProtosInOrder(a))
+		})
+	})
+
+	Describe("ReceiveProtosConsistingOf", func() {
+		It("should match the messages in any order", func() {
+			Expect(&reasonsStream{reasons: []string{"B", "A"}, err: io.EOF}).To(ReceiveProtosConsistingOf(a, b))
+			Expect(&reasonsStream{reasons: []string{"A"}, err: io.EOF}).ToNot(ReceiveProtosConsistingOf(a, b))
+		})
+	})
+
+	Describe("EndWithStatus", func() {
+		It("should match the status the stream ended with", func() {
+			Expect(&reasonsStream{err: io.EOF}).To(EndWithStatus(HaveStatusCode(Equal(codes.OK))))
+			Expect(&reasonsStream{err: status.Error(codes.NotFound, "not found")}).To(EndWithStatus(HaveStatusCode(Equal(codes.NotFound))))
+			Expect(&reasonsStream{err: io.EOF}).ToNot(EndWithStatus(HaveStatusCode(Equal(codes.NotFound))))
+		})
+	})
+
+	Describe("ReceiveWithin", func() {
+		It("should match a stream that ends in time", func() {
+			Expect(&reasonsStream{reasons: []string{"A", "B"}, err: io.EOF}).To(ReceiveWithin(time.Second,
+				ReceiveProtosInOrder(a, b),
+				EndWithStatus(HaveStatusCode(Equal(codes.OK))),
+			))
+		})
+
+		It("should not match a hung stream", func() {
+			Expect(&reasonsStream{reasons: []string{"A"}}).ToNot(ReceiveWithin(10 * time.Millisecond))
+		})
+	})
+
+	Describe("StreamTimeout", func() {
+		It("should fail the stream matchers on hung streams", func() {
+			defer func(previous time.Duration) { StreamTimeout = previous }(StreamTimeout)
+			StreamTimeout = 10 * time.Millisecond
+
+			_, err := ReceiveProtosInOrder(a).Match(&reasonsStream{reasons: []string{"A"}})
+			Expect(err).To(MatchError(ContainSubstring("the stream did not end in time")))
+		})
+	})
+
+	Describe("CancelOnTimeout", func() {
+		It("should cancel a hung stream when the timeout elapses", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			Expect(CancelOnTimeout(&reasonsStream{reasons: []string{"A"}, ctx: ctx}, cancel)).ToNot(ReceiveWithin(10 * time.Millisecond))
+			Expect(ctx.Err()).To(MatchError(context.Canceled))
+		})
+
+		It("should not cancel a stream that ends in time", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			Expect(CancelOnTimeout(&reasonsStream{reasons: []string{"A"}, err: io.EOF}, cancel)).To(ReceiveProtosInOrder(a))
+			Expect(ctx.Err()).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("DrainStream", func() {
+		It("should allow several assertions on the same stream", func() {
+			result, err := DrainStream(&reasonsStream{reasons: []string{"A", "B"}, err: status.Error(codes.Aborted, "aborted")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(ReceiveProtosConsistingOf(b, a))
+			Expect(result).To(EndWithStatus(HaveStatusCode(Equal(codes.Aborted))))
+		})
+	})
+})
+
+func ExampleReceiveWithin() {
+	stream := &reasonsStream{reasons: []string{"BOOK_CREATED"}, err: io.EOF}
+
+	Expect(stream).To(ReceiveWithin(time.Second,
+		ReceiveProtosInOrder(&errdetails.ErrorInfo{Reason: "BOOK_CREATED"}),
+		EndWithStatus(HaveStatusCode(Equal(codes.OK))),
+	))
+}